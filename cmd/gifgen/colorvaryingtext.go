@@ -0,0 +1,44 @@
+package main
+
+import (
+	"gif/render"
+)
+
+func runColorVaryingText(args []string) error {
+	fs, output := newFlagSet("colorvarying")
+	delay := fs.Float64("delay", 100, "frame delay in milliseconds")
+	font := fontFlag(fs)
+	frames := fs.Int("frames", 30, "number of frames (1-60)")
+	height := fs.Int("height", 400, "canvas height")
+	text := fs.String("text", "SALE", "text to draw")
+	width := fs.Int("width", 600, "canvas width")
+	colorScheme := fs.String("colorScheme", "complementary", "complementary, monochromatic, triadic or analogous")
+	padding := fs.Int("padding", 40, "padding around the text")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	varying, err := render.NewColorVaryingText(render.ColorVaryingTextOptions{
+		Delay:       *delay,
+		Font:        *font,
+		Frames:      *frames,
+		Height:      *height,
+		Text:        *text,
+		Width:       *width,
+		ColorScheme: *colorScheme,
+		Padding:     *padding,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	b, err := varying.Create()
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, b)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"gif/render"
+)
+
+func runCountdown(args []string) error {
+	var TEN_DAYS = time.Hour * 24 * 10
+
+	fs, output := newFlagSet("countdown")
+	background := fs.String("background", "#000000", "background color (hex)")
+	color := fs.String("color", "#ffffff", "text/progress color (hex)")
+	date := fs.String("date", time.Now().Add(TEN_DAYS).Format("2006-01-02T15:04:05.000Z"), "target date (RFC 3339, YYYY-MM-DD, YYYY-MM-DD HH:MM or Unix time)")
+	direction := fs.String("direction", "down", "down counts down to -date, up counts time elapsed since it")
+	expired := fs.String("expired", "", "what a finished countdown shows: zero, text or image")
+	expiredBackground := fs.String("expiredBackground", "", "background color once finished (hex)")
+	expiredColor := fs.String("expiredColor", "", "text color once finished (hex)")
+	expiredImage := fs.String("expiredImage", "", "PNG, JPEG or GIF file shown once finished")
+	expiredText := fs.String("expiredText", "", "message shown once finished (defaults to a localized \"ended\")")
+	font := fontFlag(fs)
+	frames := fs.Int("frames", 10, "number of frames (1-60)")
+	height := fs.Int("height", 200, "canvas height")
+	kind := fs.String("kind", "rounded", "basic, rounded, rounded-ticks, rounded-dots, flip, digital or bars")
+	lang := fs.String("lang", "en", "language code")
+	now := fs.String("now", "", "render as of this instant instead of the current time (RFC 3339, YYYY-MM-DD HH:MM or Unix time)")
+	start := fs.String("start", "", "with -kind bars, draw an overall progress bar from this date to -date")
+	timezone := fs.String("timezone", "UTC", "IANA time zone (America/Sao_Paulo) or UTC offset (-3, +05:30) of -date")
+	units := fs.String("units", "days,hours,minutes,seconds", "comma separated units among weeks, days, hours, minutes and seconds")
+	width := fs.Int("width", 700, "canvas width")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var nowTime time.Time
+
+	if *now != "" {
+		t, err := render.ParseDate(*now)
+
+		if err != nil {
+			return err
+		}
+
+		nowTime = t
+	}
+
+	var expiredImageBytes []byte
+
+	if *expiredImage != "" {
+		b, err := os.ReadFile(*expiredImage)
+
+		if err != nil {
+			return err
+		}
+
+		expiredImageBytes = b
+	}
+
+	countdown, err := render.NewCountdown(render.CountdownOptions{
+		Background:        *background,
+		Color:             *color,
+		Direction:         *direction,
+		Expired:           *expired,
+		ExpiredBackground: *expiredBackground,
+		ExpiredColor:      *expiredColor,
+		ExpiredImage:      expiredImageBytes,
+		ExpiredText:       *expiredText,
+		Font:              *font,
+		Frames:            *frames,
+		Height:            *height,
+		Kind:              *kind,
+		Lang:              *lang,
+		Now:               nowTime,
+		StartDate:         *start,
+		TargetDate:        *date,
+		Timezone:          *timezone,
+		Units:             strings.Split(*units, ","),
+		Width:             *width,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	b, err := countdown.Create()
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, b)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"gif/render"
+)
+
+func runFlashingLetters(args []string) error {
+	fs, output := newFlagSet("flashingletters")
+	background := fs.String("background", "#000000", "background color (hex)")
+	color := fs.String("color", "#ffffff", "text color (hex)")
+	delay := fs.Float64("delay", 100, "frame delay in milliseconds")
+	font := fontFlag(fs)
+	frames := fs.Int("frames", 20, "number of frames (1-60)")
+	height := fs.Int("height", 200, "canvas height")
+	seed := fs.Int64("seed", 0, "random seed (0 derives it from the other options)")
+	text := fs.String("text", "SALE", "text to flash")
+	width := fs.Int("width", 400, "canvas width")
+	flashProbability := fs.Float64("flashProbability", 0.3, "probability of a letter being hidden (0-1)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	flasher, err := render.NewFlashingLetters(render.FlashingLettersOptions{
+		Background:       *background,
+		Color:            *color,
+		Delay:            *delay,
+		Font:             *font,
+		Frames:           *frames,
+		Height:           *height,
+		Seed:             *seed,
+		Text:             *text,
+		Width:            *width,
+		FlashProbability: *flashProbability,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	b, err := flasher.Create()
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, b)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"gif/render"
+)
+
+func runFlashingText(args []string) error {
+	fs, output := newFlagSet("flashingtext")
+	background := fs.String("background", "#000000", "background color (hex)")
+	color := fs.String("color", "#ffffff", "text color (hex)")
+	delay := fs.Float64("delay", 300, "frame delay in milliseconds")
+	font := fontFlag(fs)
+	frames := fs.Int("frames", 30, "number of frames (1-60)")
+	height := fs.Int("height", 400, "canvas height")
+	seed := fs.Int64("seed", 0, "random seed (0 derives it from the other options)")
+	text := fs.String("text", "SALE", "text to flash")
+	width := fs.Int("width", 600, "canvas width")
+	words := fs.Int("words", 10, "number of word positions (1-20)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	flasher, err := render.NewFlashingText(render.FlashingTextOptions{
+		Background: *background,
+		Color:      *color,
+		Delay:      *delay,
+		Font:       *font,
+		Frames:     *frames,
+		Height:     *height,
+		Seed:       *seed,
+		Text:       *text,
+		Width:      *width,
+		Words:      *words,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	b, err := flasher.Create()
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, b)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"gif/render"
+)
+
+func runLedBanner(args []string) error {
+	fs, output := newFlagSet("ledbanner")
+	background := fs.String("background", "#000000", "background color (hex)")
+	color := fs.String("color", "#ffffff", "text color (hex)")
+	delay := fs.Float64("delay", 50, "frame delay in milliseconds")
+	font := fontFlag(fs)
+	forward := fs.Bool("forward", true, "scroll forward")
+	frames := fs.Int("frames", 10, "number of frames (1-30)")
+	height := fs.Int("height", 50, "canvas height")
+	spaceSize := fs.Int("spaceSize", 4, "spaces between text repetitions")
+	text := fs.String("text", "Hello World!", "banner text")
+	width := fs.Int("width", 800, "canvas width")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	banner, err := render.NewLedBanner(render.LedBannerOptions{
+		Background: *background,
+		Color:      *color,
+		Delay:      *delay,
+		Font:       *font,
+		Forward:    *forward,
+		Frames:     *frames,
+		Height:     *height,
+		SpaceSize:  *spaceSize,
+		Text:       *text,
+		Width:      *width,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	b, err := banner.Create()
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, b)
+}
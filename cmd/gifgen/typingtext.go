@@ -0,0 +1,46 @@
+package main
+
+import (
+	"gif/render"
+)
+
+func runTypingText(args []string) error {
+	fs, output := newFlagSet("typing")
+	background := fs.String("background", "#000000", "background color (hex)")
+	color := fs.String("color", "#ffffff", "text color (hex)")
+	delay := fs.Float64("delay", 100, "frame delay in milliseconds")
+	font := fontFlag(fs)
+	height := fs.Int("height", 200, "canvas height")
+	text := fs.String("text", "BLACK FRIDAY", "text to type")
+	width := fs.Int("width", 800, "canvas width")
+	padding := fs.Int("padding", 40, "padding around the text")
+	perWord := fs.Bool("perWord", false, "type a whole word per frame")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	typer, err := render.NewTypingText(render.TypingTextOptions{
+		Background: *background,
+		Color:      *color,
+		Delay:      *delay,
+		Font:       *font,
+		Height:     *height,
+		Text:       *text,
+		Width:      *width,
+		Padding:    *padding,
+		PerWord:    *perWord,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	b, err := typer.Create()
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, b)
+}
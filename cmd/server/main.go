@@ -0,0 +1,137 @@
+// Command server is a self-hosted net/http equivalent of the Cloudflare
+// worker in index.js.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gif/render"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.IntVar(&render.Concurrency, "concurrency", 0, "frames rendered in parallel per GIF (0 = GOMAXPROCS)")
+	fontDir := flag.String("fonts", "", "directory of TTF/OTF files to register, each under its lower-cased base name")
+	flag.Parse()
+
+	if *fontDir != "" {
+		if err := registerFonts(*fontDir); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newMux(),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(server.ListenAndServe())
+}
+
+// registerFonts registers every .ttf and .otf file in dir.
+func registerFonts(dir string) error {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+
+		if entry.IsDir() || ext != ".ttf" && ext != ".otf" {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+
+		if err != nil {
+			return err
+		}
+
+		name := strings.ToLower(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+
+		if err := render.RegisterFont(name, b); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		log.Printf("registered font %s", name)
+	}
+
+	return nil
+}
+
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /favicon.ico", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.Handle("GET /{$}", gifHandler("countdown"))
+	mux.HandleFunc("GET /{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+
+		if _, ok := render.Lookup(name); !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		gifHandler(name).ServeHTTP(w, r)
+	})
+
+	return mux
+}
+
+// gifHandler serves the renderer registered under name, writing the same
+// headers as the worker and reporting failures as JSON.
+func gifHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := render.Build(name, render.StringParams(r.URL.Query().Get))
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("cache-control", "public, max-age=3600")
+		w.Header().Set("content-type", "image/gif")
+		w.Header().Set("content-length", strconv.Itoa(len(b)))
+		w.Header().Set("content-disposition", "inline")
+		w.Write(b)
+	})
+}
+
+// writeError responds with {error, field}, using 400 for invalid options and
+// 500 for everything else.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	body := map[string]interface{}{
+		"error": err.Error(),
+		"field": nil,
+	}
+
+	var optErr *render.OptionError
+
+	if errors.As(err, &optErr) {
+		status = http.StatusBadRequest
+		body["field"] = optErr.Field
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
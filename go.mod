@@ -5,5 +5,6 @@ go 1.22.3
 require (
 	github.com/fogleman/gg v1.3.0
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
+	github.com/rivo/uniseg v0.4.7
 	golang.org/x/image v0.21.0
 )
@@ -1,14 +1,26 @@
 import './wasm_exec';
 import wasm from './main.wasm';
 
-const toNumber = (value, fallback) => {
-	if (value === '' || value === null) {
-		return fallback;
+// loadFont fetches a user font stored under its name in the FONTS KV
+// namespace or the FONTS_BUCKET R2 bucket, if either is bound.
+const loadFont = async (env, name) => {
+	if (env.FONTS) {
+		const data = await env.FONTS.get(name, 'arrayBuffer');
+
+		if (data) {
+			return data;
+		}
 	}
 
-	const number = Number(value);
+	if (env.FONTS_BUCKET) {
+		const object = await env.FONTS_BUCKET.get(name);
+
+		if (object) {
+			return object.arrayBuffer();
+		}
+	}
 
-	return isNaN(number) ? fallback : number;
+	return null;
 };
 
 export default {
@@ -34,49 +46,29 @@ export default {
 
             go.run(instance);
             
-			const base64 = globalThis.buildCountdown({
-                background: url.searchParams.get('background') || url.searchParams.get('bg') || '000',
-                color: url.searchParams.get('color') || 'fff',
-                date: new Date(url.searchParams.get('date') || '2025-01-01').toISOString(),
-                gmt: toNumber(url.searchParams.get('gmt'), 0),
-                frames: toNumber(url.searchParams.get('frames'), 10),
-                lang: url.searchParams.get('lang') || 'en',
-                kind: url.searchParams.get('kind') || 'rounded'
-            });
+            const font = url.searchParams.get('font');
+            const fontData = font ? await loadFont(env, font) : null;
+
+            if (fontData) {
+                const error = globalThis.registerFont(font, fontData);
 
-			// const base64 = globalThis.buildLedBanner({
-			// 	forward: url.searchParams.get('forward') === 'true',
-			// 	height: toNumber(url.searchParams.get('height'), 200),
-			// 	delay: toNumber(url.searchParams.get('delay'), 100),
-			// 	spaceSize: toNumber(url.searchParams.get('spaceSize'), 5),
-			// 	speed: toNumber(url.searchParams.get('speed'), 5),
-			// 	text: url.searchParams.get('text') || 'BLACK FRIDAY',
-			// 	width: toNumber(url.searchParams.get('width'), 800),
-            //     background: url.searchParams.get('background') || url.searchParams.get('bg') || 'fff',
-            //     color: url.searchParams.get('color') || '000',
-            //     frames: toNumber(url.searchParams.get('frames'), 15),
-            // });
-			
-			// const base64 = globalThis.buildFlashingText({
-			// 	forward: url.searchParams.get('forward') === 'true',
-			// 	height: toNumber(url.searchParams.get('height'), 200),
-			// 	delay: toNumber(url.searchParams.get('delay'), 200),
-			// 	spaceSize: toNumber(url.searchParams.get('spaceSize'), 5),
-			// 	speed: toNumber(url.searchParams.get('speed'), 5),
-			// 	text: url.searchParams.get('text') || 'BLACK FRIDAY',
-			// 	width: toNumber(url.searchParams.get('width'), 800),
-            //     background: url.searchParams.get('background') || url.searchParams.get('bg') || 'fff',
-            //     color: url.searchParams.get('color') || '000',
-            //     frames: toNumber(url.searchParams.get('frames'), 15),
-            // });
-
-			// const base64 = globalThis.buildTypingText({});
-
-            const binaryString = atob(base64);
-            const bytes = new Uint8Array(binaryString.length);
-
-            for (let i = 0; i < binaryString.length; i++) {
-                bytes[i] = binaryString.charCodeAt(i);
+                if (error) {
+                    return Response.json(error, {
+                        status: 400
+                    });
+                }
+            }
+
+            const name = url.pathname.slice(1) || 'countdown';
+            // Each registered factory applies its own defaults and aliases
+            const options = Object.fromEntries(url.searchParams);
+
+			const bytes = globalThis.build(name, options);
+
+            if (!(bytes instanceof Uint8Array)) {
+                return Response.json(bytes, {
+                    status: bytes.field ? 400 : 500
+                });
             }
 
             const res = new Response(bytes, {
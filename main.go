@@ -3,55 +3,133 @@
 package main
 
 import (
-	"embed"
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"image/color"
-	"strings"
 	"syscall/js"
-	"time"
-)
 
-//go:embed fonts/*.ttf
-var fontFS embed.FS
+	"gif/render"
+)
 
-var ALLOWED_FONTS = map[string]bool{
-	"impact": true,
+// legacyExports maps the original per-generator JS functions to their
+// registered renderer names.
+var legacyExports = map[string]string{
+	"buildCountdown":        "countdown",
+	"buildLedBanner":        "led-banner",
+	"buildFlashingLetters":  "flashing-letters",
+	"buildFlashingText":     "flashing-text",
+	"buildColorVaryingText": "color-varying",
+	"buildTypingText":       "typing",
 }
 
 func main() {
-	js.Global().Set("buildCountdown", js.FuncOf(buildCountdown))
-	js.Global().Set("buildLedBanner", js.FuncOf(buildLedBanner))
-	js.Global().Set("buildFlashingLetters", js.FuncOf(buildFlashingLetters))
-	js.Global().Set("buildFlashingText", js.FuncOf(buildFlashingText))
-	js.Global().Set("buildColorVaryingText", js.FuncOf(buildColorVaryingText))
-	js.Global().Set("buildTypingText", js.FuncOf(buildTypingText))
+	js.Global().Set("build", safeFunc(build))
+	js.Global().Set("buildBase64", safeFunc(buildBase64))
+	js.Global().Set("registerFont", safeFunc(registerFont))
+
+	for export, name := range legacyExports {
+		js.Global().Set(export, safeFunc(func(this js.Value, args []js.Value) interface{} {
+			return buildBase64(this, []js.Value{js.ValueOf(name), args[0]})
+		}))
+	}
 
 	select {}
 }
 
-func parseHexString(s string) color.Color {
-	var r, g, b uint8
+// build is exported to JS as build(name, options) and dispatches to any
+// registered renderer, returning the GIF as a Uint8Array.
+func build(this js.Value, args []js.Value) interface{} {
+	b, err := buildArgs(args)
 
-	if !strings.HasPrefix(s, "#") {
-		s = "#" + s
+	if err != nil {
+		return jsError(err)
 	}
 
-	if len(s) == 7 {
-		fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b)
-	} else if len(s) == 4 {
-		fmt.Sscanf(s, "#%1x%1x%1x", &r, &g, &b)
-		r *= 17
-		g *= 17
-		b *= 17
-	} else {
-		r, g, b = 255, 255, 255
+	array := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(array, b)
+
+	return array
+}
+
+// buildBase64 is build returning a base64 string, as the legacy build*
+// exports always did.
+func buildBase64(this js.Value, args []js.Value) interface{} {
+	b, err := buildArgs(args)
+
+	if err != nil {
+		return jsError(err)
 	}
 
-	return color.RGBA{r, g, b, 255}
+	return base64.StdEncoding.EncodeToString(b)
 }
 
-func parseDateString(s string) (time.Time, error) {
-	layout := "2006-01-02T15:04:05.000Z"
+// registerFont is exported to JS as registerFont(name, bytes), bytes being a
+// Uint8Array or ArrayBuffer holding a TTF/OTF font. It returns null once the
+// font is selectable with the font option.
+func registerFont(this js.Value, args []js.Value) interface{} {
+	if args[0].Type() != js.TypeString {
+		return jsError(&render.OptionError{Field: "font", Err: errors.New("name must be a string")})
+	}
+
+	if len(args) < 2 || !args[1].InstanceOf(js.Global().Get("Uint8Array")) && !args[1].InstanceOf(js.Global().Get("ArrayBuffer")) {
+		return jsError(&render.OptionError{Field: "font", Err: errors.New("font data must be a Uint8Array or ArrayBuffer")})
+	}
+
+	data := js.Global().Get("Uint8Array").New(args[1])
+	b := make([]byte, data.Length())
+	js.CopyBytesToGo(b, data)
+
+	if err := render.RegisterFont(args[0].String(), b); err != nil {
+		return jsError(err)
+	}
+
+	return nil
+}
 
-	return time.Parse(layout, s)
+func buildArgs(args []js.Value) ([]byte, error) {
+	if args[0].Type() != js.TypeString {
+		return nil, &render.OptionError{Field: "name", Err: errors.New("must be a string")}
+	}
+
+	options := js.ValueOf(map[string]interface{}{})
+
+	if len(args) > 1 {
+		options = args[1]
+	}
+
+	return render.Build(args[0].String(), jsParams{options})
+}
+
+// safeFunc wraps fn so that a panic (e.g. a wrongly typed option) is returned
+// to JS as an error object instead of killing the Go runtime.
+func safeFunc(fn func(this js.Value, args []js.Value) interface{}) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) (result interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = jsError(fmt.Errorf("%v", r))
+			}
+		}()
+
+		if len(args) == 0 {
+			args = []js.Value{js.ValueOf(map[string]interface{}{})}
+		}
+
+		return fn(this, args)
+	})
+}
+
+// jsError converts err into the {error, field} object returned to JS. field
+// is null unless err is a *render.OptionError.
+func jsError(err error) interface{} {
+	var field interface{}
+	var optErr *render.OptionError
+
+	if errors.As(err, &optErr) {
+		field = optErr.Field
+	}
+
+	return map[string]interface{}{
+		"error": err.Error(),
+		"field": field,
+	}
 }
@@ -0,0 +1,95 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"strconv"
+	"syscall/js"
+)
+
+// jsParams implements render.Params on top of a JS options object. Missing,
+// null and wrongly typed values yield the fallback.
+type jsParams struct {
+	v js.Value
+}
+
+func (p jsParams) get(key string) (js.Value, bool) {
+	if p.v.Type() != js.TypeObject {
+		return js.Undefined(), false
+	}
+
+	value := p.v.Get(key)
+
+	return value, !value.IsUndefined() && !value.IsNull()
+}
+
+func (p jsParams) String(key string, fallback string) string {
+	value, ok := p.get(key)
+
+	if !ok {
+		return fallback
+	}
+
+	if value.Type() == js.TypeString {
+		return value.String()
+	}
+
+	return js.Global().Call("String", value).String()
+}
+
+func (p jsParams) Int(key string, fallback int) int {
+	value, ok := p.get(key)
+
+	if !ok {
+		return fallback
+	}
+
+	switch value.Type() {
+	case js.TypeNumber:
+		return value.Int()
+	case js.TypeString:
+		if n, err := strconv.Atoi(value.String()); err == nil {
+			return n
+		}
+	}
+
+	return fallback
+}
+
+func (p jsParams) Float(key string, fallback float64) float64 {
+	value, ok := p.get(key)
+
+	if !ok {
+		return fallback
+	}
+
+	switch value.Type() {
+	case js.TypeNumber:
+		return value.Float()
+	case js.TypeString:
+		if n, err := strconv.ParseFloat(value.String(), 64); err == nil {
+			return n
+		}
+	}
+
+	return fallback
+}
+
+func (p jsParams) Bool(key string, fallback bool) bool {
+	value, ok := p.get(key)
+
+	if !ok {
+		return fallback
+	}
+
+	switch value.Type() {
+	case js.TypeBoolean:
+		return value.Bool()
+	case js.TypeString:
+		if b, err := strconv.ParseBool(value.String()); err == nil {
+			return b
+		}
+	}
+
+	return fallback
+}
@@ -1,89 +1,33 @@
-//go:build js && wasm
-
-package main
+package render
 
 import (
-	"bytes"
-	"encoding/base64"
-	"fmt"
 	"image"
 	"image/color"
-	"image/draw"
-	"image/gif"
 	"math"
 	"strings"
-	"syscall/js"
 	"unicode"
 
 	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
 	"golang.org/x/image/font"
 )
 
-func buildColorVaryingText(this js.Value, args []js.Value) interface{} {
-	delay := args[0].Get("delay")
-	frames := args[0].Get("frames")
-	height := args[0].Get("height")
-	text := args[0].Get("text")
-	width := args[0].Get("width")
-	colorScheme := args[0].Get("colorScheme")
-	padding := args[0].Get("padding")
-
-	if delay.IsUndefined() {
-		delay = js.ValueOf(100)
-	}
-
-	if frames.IsUndefined() {
-		frames = js.ValueOf(30)
-	}
-
-	if height.IsUndefined() {
-		height = js.ValueOf(400)
-	}
-
-	if text.IsUndefined() {
-		text = js.ValueOf("SALE")
-	}
-
-	if width.IsUndefined() {
-		width = js.ValueOf(600)
-	}
-
-	if colorScheme.IsUndefined() {
-		colorScheme = js.ValueOf("complementary")
-	}
-
-	if padding.IsUndefined() {
-		padding = js.ValueOf(40)
-	}
-
-	varying := NewColorVaryingText(ColorVaryingTextOptions{
-		Delay:       delay.Float(),
-		Frames:      frames.Int(),
-		Height:      height.Int(),
-		Text:        text.String(),
-		Width:       width.Int(),
-		ColorScheme: colorScheme.String(),
-		Padding:     padding.Int(),
-	})
-
-	b := varying.Create()
-
-	return base64.StdEncoding.EncodeToString(b)
-}
-
 type ColorVaryingText struct {
 	delay       float64
+	font        string
 	frames      int
 	height      int
 	text        string
 	width       int
 	colorScheme string
 	padding     int
+
+	fontFace font.Face
+	layout   TextLayout
 }
 
 type ColorVaryingTextOptions struct {
 	Delay       float64
+	Font        string
 	Frames      int
 	Height      int
 	Text        string
@@ -102,15 +46,25 @@ type TextLayout struct {
 	fontSize float64
 }
 
-func NewColorVaryingText(opts ColorVaryingTextOptions) *ColorVaryingText {
+func NewColorVaryingText(opts ColorVaryingTextOptions) (*ColorVaryingText, error) {
 	if opts.Frames < 1 {
 		opts.Frames = 1
 	} else if opts.Frames > 60 {
 		opts.Frames = 60
 	}
 
-	return &ColorVaryingText{
+	opts.Width = clampCanvasSide(opts.Width, 600)
+	opts.Height = clampCanvasSide(opts.Height, 400)
+
+	fontName, err := resolveFont(opts.Font)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cv := &ColorVaryingText{
 		delay:       opts.Delay,
+		font:        fontName,
 		frames:      opts.Frames,
 		height:      opts.Height,
 		text:        strings.TrimSpace(opts.Text),
@@ -118,41 +72,68 @@ func NewColorVaryingText(opts ColorVaryingTextOptions) *ColorVaryingText {
 		colorScheme: opts.ColorScheme,
 		padding:     opts.Padding,
 	}
-}
-
-func (cv *ColorVaryingText) Create() []byte {
-	var images []*image.Paletted
-	var delays []int
 
-	layout := cv.calculateTextLayout()
+	layout, err := cv.calculateTextLayout()
+	if err != nil {
+		return nil, err
+	}
 
 	fontFace, err := cv.loadFont(layout.fontSize)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	for i := 0; i < cv.frames; i++ {
-		frame := cv.createFrame(fontFace, layout, i)
-		images = append(images, frame)
-		delays = append(delays, int(cv.delay/10))
-	}
+	cv.layout = layout
+	cv.fontFace = fontFace
+
+	return cv, nil
+}
+
+func init() {
+	Register("color-varying", func(p Params) (Renderer, error) {
+		varying, err := NewColorVaryingText(ColorVaryingTextOptions{
+			Delay:       p.Float("delay", 100),
+			Font:        p.String("font", ""),
+			Frames:      p.Int("frames", 30),
+			Height:      p.Int("height", 400),
+			Text:        p.String("text", "SALE"),
+			Width:       p.Int("width", 600),
+			ColorScheme: p.String("colorScheme", "complementary"),
+			Padding:     p.Int("padding", 40),
+		})
+
+		if err != nil {
+			return nil, err
+		}
 
-	b := new(bytes.Buffer)
-	gif.EncodeAll(b, &gif.GIF{
-		Image: images,
-		Delay: delays,
+		return varying, nil
 	})
-	return b.Bytes()
 }
 
-func (cv *ColorVaryingText) calculateTextLayout() TextLayout {
+func (cv *ColorVaryingText) Create() ([]byte, error) {
+	return Encode(cv)
+}
+
+func (cv *ColorVaryingText) Frames() int {
+	return cv.frames
+}
+
+func (cv *ColorVaryingText) Delay(i int) int {
+	return int(cv.delay / 10)
+}
+
+func (cv *ColorVaryingText) Palette(i int) color.Palette {
+	return cv.generatePalette(cv.getColorPair(i))
+}
+
+func (cv *ColorVaryingText) calculateTextLayout() (TextLayout, error) {
 	fontSize := float64(cv.height) * 0.5
 	dc := gg.NewContext(cv.width, cv.height)
 
 	for {
-		font, err := cv.loadFont(fontSize)
+		font, err := measureFont(cv.font, fontSize, 144)
 		if err != nil {
-			panic(err)
+			return TextLayout{}, err
 		}
 		dc.SetFontFace(font)
 
@@ -169,7 +150,7 @@ func (cv *ColorVaryingText) calculateTextLayout() TextLayout {
 			return TextLayout{
 				lines:    lines,
 				fontSize: fontSize,
-			}
+			}, nil
 		}
 
 		fontSize *= 0.9
@@ -179,7 +160,10 @@ func (cv *ColorVaryingText) calculateTextLayout() TextLayout {
 		}
 	}
 
-	font, _ := cv.loadFont(fontSize)
+	font, err := measureFont(cv.font, fontSize, 144)
+	if err != nil {
+		return TextLayout{}, err
+	}
 	dc.SetFontFace(font)
 	words := cv.splitIntoWords(cv.text)
 	lines := cv.arrangeWords(dc, words, float64(cv.width-2*cv.padding))
@@ -187,7 +171,7 @@ func (cv *ColorVaryingText) calculateTextLayout() TextLayout {
 	return TextLayout{
 		lines:    lines,
 		fontSize: fontSize,
-	}
+	}, nil
 }
 
 func (cv *ColorVaryingText) splitIntoWords(text string) []string {
@@ -246,7 +230,9 @@ func (cv *ColorVaryingText) arrangeWords(dc *gg.Context, words []string, maxWidt
 	return lines
 }
 
-func (cv *ColorVaryingText) createFrame(fontFace font.Face, layout TextLayout, frameNum int) *image.Paletted {
+func (cv *ColorVaryingText) Frame(frameNum int) (image.Image, error) {
+	fontFace := cv.fontFace
+	layout := cv.layout
 	dc := gg.NewContext(cv.width, cv.height)
 
 	colors := cv.getColorPair(frameNum)
@@ -269,12 +255,7 @@ func (cv *ColorVaryingText) createFrame(fontFace font.Face, layout TextLayout, f
 		dc.DrawString(line, x, y)
 	}
 
-	bounds := dc.Image().Bounds()
-	palette := cv.generatePalette(colors)
-	palettedImage := image.NewPaletted(bounds, palette)
-	draw.Draw(palettedImage, palettedImage.Rect, dc.Image(), bounds.Min, draw.Src)
-
-	return palettedImage
+	return dc.Image(), nil
 }
 
 func (cv *ColorVaryingText) getColorPair(frameNum int) ColorPair {
@@ -372,18 +353,5 @@ func (cv *ColorVaryingText) generatePalette(colors ColorPair) color.Palette {
 }
 
 func (cv *ColorVaryingText) loadFont(size float64) (font.Face, error) {
-	fontBytes, err := fontFS.ReadFile("fonts/impact.ttf")
-	if err != nil {
-		return nil, fmt.Errorf("failed to read embedded font file: %v", err)
-	}
-
-	font, err := truetype.Parse(fontBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse font: %v", err)
-	}
-
-	return truetype.NewFace(font, &truetype.Options{
-		Size: size,
-		DPI:  144,
-	}), nil
+	return loadFont(cv.font, size, 144)
 }
@@ -0,0 +1,594 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+)
+
+// The countdown layouts are designed for a 700x200 canvas; other sizes scale
+// radii, strokes, spacing and fonts proportionally.
+const (
+	countdownBaseWidth  = 700
+	countdownBaseHeight = 200
+)
+
+type Countdown struct {
+	bg         color.Color
+	color      color.Color
+	countUp    bool
+	endDate    time.Time // the target as given, targetDate is moved to now once reached
+	expiry     countdownExpiry
+	expiredBg  color.Color
+	expiredFg  color.Color
+	font       string
+	frames     int
+	kind       string
+	lang       string
+	now        time.Time
+	scale      float64
+	startDate  time.Time
+	targetDate time.Time
+	units      []countdownUnit
+	w, h       int
+}
+
+type CountdownOptions struct {
+	Background string
+	Font       string
+	Color      string
+	Direction  string // "down" (default) counts down to TargetDate, "up" counts time elapsed since it
+	// Expired selects what a finished countdown shows from the first frame
+	// at or past TargetDate: "zero" (the default) keeps the all-zero clock,
+	// "text" shows ExpiredText (or a localized "ended") and "image" shows
+	// ExpiredImage. ExpiredBackground and ExpiredColor, when set, replace
+	// the colors once finished.
+	Expired           string
+	ExpiredBackground string
+	ExpiredColor      string
+	ExpiredImage      []byte // PNG, JPEG or GIF
+	ExpiredText       string
+	Frames            int
+	GMT               int // deprecated: hours added to TargetDate and StartDate when Timezone is empty, as earlier versions did
+	Height            int
+	Lang              string
+	Kind              string
+	// Now is the instant the first frame is rendered at. The zero value
+	// means time.Now(); set it to get reproducible output.
+	Now time.Time
+	// StartDate, parsed like TargetDate, adds an overall progress bar from
+	// it to TargetDate to the "bars" kind.
+	StartDate  string
+	TargetDate string   // wall-clock time in Timezone unless it has "Z" or a numeric offset
+	Timezone   string   // IANA name ("America/Sao_Paulo") or UTC offset ("-3", "+05:30")
+	Units      []string // any of weeks, days, hours, minutes, seconds
+	Width      int
+}
+
+func NewCountdown(opts CountdownOptions) (*Countdown, error) {
+	var countUp bool
+
+	switch opts.Direction {
+	case "", "down":
+	case "up":
+		countUp = true
+	default:
+		return nil, &OptionError{Field: "direction", Err: fmt.Errorf("unknown direction %q", opts.Direction)}
+	}
+
+	fontName, err := resolveFont(opts.Font)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expiry, err := newCountdownExpiry(opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := parseUnits(opts.Units)
+
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := parseTimezone(opts.Timezone)
+
+	if err != nil {
+		return nil, &OptionError{Field: "timezone", Err: err}
+	}
+
+	targetDate, err := parseDateString(opts.TargetDate, loc)
+
+	if err != nil {
+		return nil, &OptionError{Field: "date", Err: err}
+	}
+
+	// Earlier versions added GMT hours to the target instead of reading it
+	// in that offset; keep doing so for existing URLs
+	var gmtShift time.Duration
+
+	if opts.Timezone == "" {
+		gmtShift = time.Duration(opts.GMT) * time.Hour
+		targetDate = targetDate.Add(gmtShift)
+	}
+
+	var startDate time.Time
+
+	if opts.StartDate != "" {
+		startDate, err = parseDateString(opts.StartDate, loc)
+
+		if err != nil {
+			return nil, &OptionError{Field: "start", Err: err}
+		}
+
+		startDate = startDate.Add(gmtShift)
+
+		if !startDate.Before(targetDate) {
+			return nil, &OptionError{Field: "start", Err: fmt.Errorf("%s is not before the target date", opts.StartDate)}
+		}
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	now := opts.Now
+
+	if opts.Frames < 1 {
+		opts.Frames = 1
+	} else if opts.Frames > 60 {
+		opts.Frames = 60
+	}
+
+	opts.Width = clampCanvasSide(opts.Width, countdownBaseWidth)
+	opts.Height = clampCanvasSide(opts.Height, countdownBaseHeight)
+
+	endDate := targetDate
+
+	if countUp {
+		if now.Before(targetDate) || now.Equal(targetDate) {
+			targetDate = opts.Now
+			opts.Frames = 1
+		}
+	} else if now.After(targetDate) || now.Equal(targetDate) {
+		targetDate = opts.Now
+		opts.Frames = 1
+	}
+
+	bg, fg := parseHexString(opts.Background), parseHexString(opts.Color)
+	expiredBg, expiredFg := bg, fg
+
+	if opts.ExpiredBackground != "" {
+		expiredBg = parseHexString(opts.ExpiredBackground)
+	}
+
+	if opts.ExpiredColor != "" {
+		expiredFg = parseHexString(opts.ExpiredColor)
+	}
+
+	return &Countdown{
+		bg:         bg,
+		color:      fg,
+		countUp:    countUp,
+		endDate:    endDate,
+		expiry:     expiry,
+		expiredBg:  expiredBg,
+		expiredFg:  expiredFg,
+		font:       fontName,
+		frames:     opts.Frames,
+		kind:       opts.Kind,
+		lang:       opts.Lang,
+		h:          opts.Height,
+		now:        opts.Now,
+		scale:      countdownScale(opts.Width, opts.Height, len(units)),
+		startDate:  startDate,
+		targetDate: targetDate,
+		units:      units,
+		w:          opts.Width,
+	}, nil
+}
+
+// countdownScale fits the base layout, which has room for four units, into
+// a w x h canvas showing n units.
+func countdownScale(w, h, n int) float64 {
+	baseWidth := float64(countdownBaseWidth)
+
+	if n > 4 {
+		baseWidth = baseWidth * float64(n) / 4
+	}
+
+	return math.Min(float64(w)/baseWidth, float64(h)/countdownBaseHeight)
+}
+
+func init() {
+	Register("countdown", func(p Params) (Renderer, error) {
+		var TEN_DAYS = time.Hour * 24 * 10
+
+		now := time.Now()
+
+		if value := p.String("now", ""); value != "" {
+			t, err := ParseDate(value)
+
+			if err != nil {
+				return nil, &OptionError{Field: "now", Err: err}
+			}
+
+			now = t
+		}
+
+		var expiredImage []byte
+
+		if value := p.String("expiredImage", ""); value != "" {
+			b, err := decodeBase64Param(value)
+
+			if err != nil {
+				return nil, &OptionError{Field: "expiredImage", Err: err}
+			}
+
+			expiredImage = b
+		}
+
+		countdown, err := NewCountdown(CountdownOptions{
+			Background:        p.String("background", p.String("bg", "#000000")),
+			Color:             p.String("color", "#ffffff"),
+			Direction:         p.String("direction", "down"),
+			Expired:           p.String("expired", ""),
+			ExpiredBackground: p.String("expiredBackground", ""),
+			ExpiredColor:      p.String("expiredColor", ""),
+			ExpiredImage:      expiredImage,
+			ExpiredText:       p.String("expiredText", ""),
+			Font:              p.String("font", ""),
+			Frames:            p.Int("frames", 10),
+			Height:            p.Int("height", countdownBaseHeight),
+			Kind:              p.String("kind", "rounded"),
+			Lang:              p.String("lang", "en"),
+			Now:               now,
+			StartDate:         p.String("start", ""),
+			TargetDate:        p.String("date", now.Add(TEN_DAYS).Format(time.RFC3339)),
+			GMT:               p.Int("gmt", 0),
+			Timezone:          p.String("timezone", p.String("tz", "")),
+			Units:             strings.Split(p.String("units", ""), ","),
+			Width:             p.Int("width", countdownBaseWidth),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return countdown, nil
+	})
+}
+
+func (c *Countdown) Create() ([]byte, error) {
+	return Encode(c)
+}
+
+func (c *Countdown) Frames() int {
+	return c.frames * c.steps()
+}
+
+func (c *Countdown) Delay(i int) int {
+	steps := c.steps()
+
+	if i%steps < steps-1 {
+		return flipStepDelay
+	}
+
+	return 100 - (steps-1)*flipStepDelay
+}
+
+func (c *Countdown) Palette(i int) color.Palette {
+	if c.expiredAt(i / c.steps()) {
+		return c.withExpiredColors().expiredPalette()
+	}
+
+	return blendPalette(c.color, c.bg)
+}
+
+func (c *Countdown) Frame(i int) (image.Image, error) {
+	steps := c.steps()
+	second, step := i/steps, i%steps
+
+	if c.expiredAt(second) {
+		c = c.withExpiredColors()
+
+		if frame := c.createFrameExpired(); frame != nil {
+			return frame, nil
+		}
+	}
+
+	values := c.valuesAt(second)
+
+	switch c.kind {
+	default:
+		return c.createFrameBasic(values), nil
+	case "rounded", "rounded-ticks", "rounded-dots":
+		return c.createFrameRounded(values), nil
+	case "bars":
+		return c.createFrameBars(values, second), nil
+	case "digital":
+		return c.createFrameDigital(values, second), nil
+	case "flip":
+		progress := float64(step+1) / float64(steps)
+
+		return c.createFrameFlip(c.valuesAt(second-1), values, progress), nil
+	}
+}
+
+// expiredAt reports whether a countdown has reached its target the given
+// number of seconds after now, which may happen partway through the GIF.
+func (c *Countdown) expiredAt(second int) bool {
+	return !c.countUp && !c.now.Add(time.Duration(second)*time.Second).Before(c.endDate)
+}
+
+// withExpiredColors returns a copy of c drawing with the expired colors.
+func (c *Countdown) withExpiredColors() *Countdown {
+	e := *c
+	e.bg, e.color = c.expiredBg, c.expiredFg
+
+	return &e
+}
+
+// valuesAt splits the time left (or elapsed, when counting up) the given
+// number of seconds after now into the shown units. Time past the target
+// counts as zero.
+func (c *Countdown) valuesAt(second int) []int {
+	now := c.now.Add(time.Duration(second) * time.Second)
+	timeLeft := c.targetDate.Sub(now)
+
+	if c.countUp {
+		timeLeft = now.Sub(c.targetDate)
+	}
+
+	return splitDuration(max(timeLeft, 0), c.units)
+}
+
+func (c *Countdown) blendColorByAlpha(alpha uint8) color.Color {
+	a := float64(alpha) / 255.0
+	r1, g1, b1, _ := c.color.RGBA()
+	r2, g2, b2, _ := c.bg.RGBA()
+
+	r := uint8(float64(r1>>8)*a + float64(r2>>8)*(1-a))
+	g := uint8(float64(g1>>8)*a + float64(g2>>8)*(1-a))
+	b := uint8(float64(b1>>8)*a + float64(b2>>8)*(1-a))
+
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
+
+// translations holds the unit labels and the "ended" text of each
+// supported language.
+var translations = map[string]map[string]string{
+	"ar": {"weeks": "أسابيع", "days": "أيام", "hours": "ساعات", "minutes": "دقائق", "seconds": "ثواني", "ended": "انتهى"},
+	"bg": {"weeks": "седмици", "days": "дни", "hours": "часа", "minutes": "минути", "seconds": "секунди", "ended": "Приключи"},
+	"cs": {"weeks": "týdny", "days": "dny", "hours": "hodiny", "minutes": "minuty", "seconds": "sekundy", "ended": "Skončilo"},
+	"da": {"weeks": "uger", "days": "dage", "hours": "timer", "minutes": "minutter", "seconds": "sekunder", "ended": "Slut"},
+	"de": {"weeks": "Wochen", "days": "Tage", "hours": "Stunden", "minutes": "Minuten", "seconds": "Sekunden", "ended": "Beendet"},
+	"el": {"weeks": "εβδομάδες", "days": "ημέρες", "hours": "ώρες", "minutes": "λεπτά", "seconds": "δευτερόλεπτα", "ended": "Έληξε"},
+	"en": {"weeks": "weeks", "days": "days", "hours": "hours", "minutes": "minutes", "seconds": "seconds", "ended": "Ended"},
+	"es": {"weeks": "semanas", "days": "días", "hours": "horas", "minutes": "minutos", "seconds": "segundos", "ended": "Finalizado"},
+	"fa": {"weeks": "هفته", "days": "روز", "hours": "ساعت", "minutes": "دقیقه", "seconds": "ثانیه", "ended": "پایان یافت"},
+	"fi": {"weeks": "viikkoa", "days": "päivää", "hours": "tuntia", "minutes": "minuuttia", "seconds": "sekuntia", "ended": "Päättynyt"},
+	"fr": {"weeks": "semaines", "days": "jours", "hours": "heures", "minutes": "minutes", "seconds": "secondes", "ended": "Terminé"},
+	"he": {"weeks": "שבועות", "days": "ימים", "hours": "שעות", "minutes": "דקות", "seconds": "שניות", "ended": "הסתיים"},
+	"hi": {"weeks": "सप्ताह", "days": "दिन", "hours": "घंटे", "minutes": "मिनट", "seconds": "सेकंड", "ended": "समाप्त"},
+	"hu": {"weeks": "hét", "days": "nap", "hours": "óra", "minutes": "perc", "seconds": "másodperc", "ended": "Lejárt"},
+	"it": {"weeks": "settimane", "days": "giorni", "hours": "ore", "minutes": "minuti", "seconds": "secondi", "ended": "Terminato"},
+	"ja": {"weeks": "週", "days": "日", "hours": "時間", "minutes": "分", "seconds": "秒", "ended": "終了"},
+	"ko": {"weeks": "주", "days": "일", "hours": "시간", "minutes": "분", "seconds": "초", "ended": "종료"},
+	"lt": {"weeks": "savaitės", "days": "dienos", "hours": "valandos", "minutes": "minutės", "seconds": "sekundės", "ended": "Baigėsi"},
+	"nl": {"weeks": "weken", "days": "dagen", "hours": "uren", "minutes": "minuten", "seconds": "seconden", "ended": "Afgelopen"},
+	"no": {"weeks": "uker", "days": "dager", "hours": "timer", "minutes": "minutter", "seconds": "sekunder", "ended": "Avsluttet"},
+	"pl": {"weeks": "tygodnie", "days": "dni", "hours": "godziny", "minutes": "minuty", "seconds": "sekundy", "ended": "Zakończono"},
+	"pt": {"weeks": "semanas", "days": "dias", "hours": "horas", "minutes": "minutos", "seconds": "segundos", "ended": "Encerrado"},
+	"ro": {"weeks": "săptămâni", "days": "zile", "hours": "ore", "minutes": "minute", "seconds": "secunde", "ended": "Încheiat"},
+	"ru": {"weeks": "недели", "days": "дни", "hours": "часы", "minutes": "минуты", "seconds": "секунды", "ended": "Завершено"},
+	"sk": {"weeks": "týždne", "days": "dni", "hours": "hodiny", "minutes": "minúty", "seconds": "sekundy", "ended": "Skončilo"},
+	"sv": {"weeks": "veckor", "days": "dagar", "hours": "timmar", "minutes": "minuter", "seconds": "sekunder", "ended": "Avslutat"},
+	"th": {"weeks": "สัปดาห์", "days": "วัน", "hours": "ชั่วโมง", "minutes": "นาที", "seconds": "วินาที", "ended": "สิ้นสุดแล้ว"},
+	"tr": {"weeks": "hafta", "days": "gün", "hours": "saat", "minutes": "dakika", "seconds": "saniye", "ended": "Sona erdi"},
+	"uk": {"weeks": "тижні", "days": "дні", "hours": "години", "minutes": "хвилини", "seconds": "секунди", "ended": "Завершено"},
+	"vi": {"weeks": "tuần", "days": "ngày", "hours": "giờ", "minutes": "phút", "seconds": "giây", "ended": "Đã kết thúc"},
+	"zh": {"weeks": "周", "days": "天", "hours": "小时", "minutes": "分钟", "seconds": "秒", "ended": "已结束"},
+}
+
+func (c *Countdown) getTranslation(key string) string {
+	if trans, ok := translations[c.lang]; ok {
+		if value, ok := trans[key]; ok {
+			return value
+		}
+	}
+
+	return key
+}
+
+func (c *Countdown) createFrameBasic(values []int) image.Image {
+	dc := gg.NewContext(c.w, c.h)
+
+	dc.SetColor(c.bg)
+	dc.Clear()
+
+	if face, err := loadFont(c.font, 60*c.scale, 0); err == nil {
+		dc.SetFontFace(face)
+	}
+
+	// Draw countdown text
+	dc.SetColor(c.color)
+	var parts []string
+
+	for i, unit := range c.units {
+		parts = append(parts, fmt.Sprintf("%d%s", values[i], unit.suffix))
+	}
+
+	countdownText := strings.Join(parts, " ")
+	dc.DrawStringAnchored(countdownText, float64(c.w)/2, float64(c.h)/2, 0.5, 0.5)
+
+	return dc.Image()
+}
+
+// unitLabel returns the translated label of unit, shaped for drawing.
+func (c *Countdown) unitLabel(unit countdownUnit) string {
+	return shapeText(c.getTranslation(unit.name))
+}
+
+// unitX returns the center of the i-th unit of a row spacing apart, the
+// largest unit being rightmost for right-to-left languages.
+func (c *Countdown) unitX(i int, spacing float64) float64 {
+	if isRTL(c.lang) {
+		i = len(c.units) - 1 - i
+	}
+
+	startX := float64(c.w)/2 - float64(len(c.units)-1)/2*spacing
+
+	return startX + float64(i)*spacing
+}
+
+func (c *Countdown) createFrameRounded(values []int) image.Image {
+	dc := gg.NewContext(c.w, c.h)
+
+	dc.SetColor(c.bg)
+	dc.Clear()
+
+	circleRadius := 65 * c.scale
+	spacing := 160 * c.scale
+	y := float64(c.h) / 2
+
+	for i, unit := range c.units {
+		x := c.unitX(i, spacing)
+
+		if c.kind == "rounded-ticks" || c.kind == "rounded-dots" {
+			c.drawDotsOrTicks(dc, x, y, circleRadius, values[i], unit.max, c.unitLabel(unit))
+		} else {
+			c.drawCircle(dc, x, y, circleRadius, values[i], unit.max, c.unitLabel(unit))
+		}
+	}
+
+	return dc.Image()
+}
+
+func (c *Countdown) drawCircle(dc *gg.Context, x, y, radius float64, value int, max int, label string) {
+	// Draw outer circle
+	dc.SetColor(c.blendColorByAlpha(50))
+	dc.SetLineWidth(10 * c.scale)
+	dc.DrawArc(x, y, radius, 0, 2*math.Pi)
+	dc.Stroke()
+
+	// Draw progress arc
+	dc.SetColor(c.color)
+	dc.SetLineWidth(10 * c.scale)
+	startAngle := -math.Pi / 2
+
+	// Rolled-up units (e.g. hours without days) can exceed max; stop at a
+	// full circle
+	fraction := math.Max(0, math.Min(1, float64(value)/float64(max)))
+	angle := startAngle + fraction*2*math.Pi
+
+	if c.countUp {
+		// Fill counter-clockwise when counting up
+		dc.DrawArc(x, y, radius, 2*startAngle-angle, startAngle)
+	} else {
+		dc.DrawArc(x, y, radius, startAngle, angle)
+	}
+	dc.Stroke()
+
+	// Draw value text
+	face, err := loadFont(c.font, 40*c.scale, 0)
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+	dc.SetColor(c.color)
+	dc.DrawStringAnchored(fmt.Sprintf("%d", value), x, y-10*c.scale, 0.5, 0.5)
+
+	// Draw label text
+	face, err = loadFont(c.font, 16*c.scale, 0)
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+	dc.DrawStringAnchored(strings.ToUpper(label), x, y+25*c.scale, 0.5, 0.5)
+}
+
+func (c *Countdown) drawDotsOrTicks(dc *gg.Context, x, y, radius float64, value int, max int, label string) {
+	// Draw tick marks
+	dc.SetColor(c.color)
+	progress := int(float64(value) / float64(max) * float64(max))
+
+	if c.kind == "rounded-dots" {
+		c.drawDot(dc, x, y, radius, max, progress)
+	} else {
+		c.drawTick(dc, x, y, radius, max, progress)
+	}
+
+	// Draw value text
+	face, err := loadFont(c.font, 40*c.scale, 0)
+
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+	dc.SetColor(c.color)
+	dc.DrawStringAnchored(fmt.Sprintf("%d", value), x, y-10*c.scale, 0.5, 0.5)
+
+	// Draw label text
+	face, err = loadFont(c.font, 16*c.scale, 0)
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+	dc.DrawStringAnchored(strings.ToUpper(label), x, y+25*c.scale, 0.5, 0.5)
+}
+
+// direction returns 1 when progress fills clockwise and -1 when counting up.
+func (c *Countdown) direction() float64 {
+	if c.countUp {
+		return -1
+	}
+
+	return 1
+}
+
+func (c *Countdown) drawDot(dc *gg.Context, x, y, radius float64, count int, progress int) {
+	for i := 0; i < count; i++ {
+		startAngle := -math.Pi / 2
+		angle := startAngle + c.direction()*float64(i)*2*math.Pi/float64(count)
+
+		startX := x + (radius+5*c.scale)*math.Cos(angle)
+		startY := y + (radius+5*c.scale)*math.Sin(angle)
+
+		if i <= progress {
+			dc.SetColor(c.color)
+		} else {
+			dc.SetColor(c.blendColorByAlpha(50))
+		}
+
+		dc.DrawCircle(startX, startY, 3*c.scale)
+		dc.Fill()
+	}
+}
+
+func (c *Countdown) drawTick(dc *gg.Context, x, y, radius float64, count int, progress int) {
+	dc.SetLineWidth(3 * c.scale)
+
+	for i := 0; i < count; i++ {
+		startAngle := -math.Pi / 2
+		angle := startAngle + c.direction()*float64(i)*2*math.Pi/float64(count)
+
+		startX := x + (radius-5*c.scale)*math.Cos(angle)
+		startY := y + (radius-5*c.scale)*math.Sin(angle)
+		endX := x + (radius+5*c.scale)*math.Cos(angle)
+		endY := y + (radius+5*c.scale)*math.Sin(angle)
+
+		if i <= progress {
+			dc.SetColor(c.color)
+		} else {
+			dc.SetColor(c.blendColorByAlpha(50))
+		}
+
+		dc.DrawLine(startX, startY, endX, endY)
+		dc.Stroke()
+	}
+}
@@ -0,0 +1,122 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+)
+
+// createFrameBars draws one labeled horizontal bar per unit, filled like
+// drawCircle fills its arc, followed by the overall progress bar when a
+// start date is set.
+func (c *Countdown) createFrameBars(values []int, second int) image.Image {
+	dc := gg.NewContext(c.w, c.h)
+
+	dc.SetColor(c.bg)
+	dc.Clear()
+
+	rows := len(c.units)
+
+	if !c.startDate.IsZero() {
+		rows++
+	}
+
+	padding := math.Min(float64(c.w), float64(c.h)) * 0.08
+	rowHeight := (float64(c.h) - 2*padding) / float64(rows)
+	barHeight := rowHeight * 0.45
+
+	face, err := loadFont(c.font, rowHeight*0.5, 0)
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+
+	labels := make([]string, len(c.units))
+	texts := make([]string, len(c.units))
+	var labelWidth, valueWidth float64
+
+	for i, unit := range c.units {
+		labels[i] = strings.ToUpper(c.unitLabel(unit))
+		texts[i] = fmt.Sprintf("%d", values[i])
+
+		labelWidth = math.Max(labelWidth, measureWidth(dc, labels[i]))
+		valueWidth = math.Max(valueWidth, measureWidth(dc, texts[i]))
+	}
+
+	valueWidth = math.Max(valueWidth, measureWidth(dc, "100%"))
+
+	gap := rowHeight * 0.4
+	barX := padding + labelWidth + gap
+	barWidth := float64(c.w) - padding - valueWidth - gap - barX
+
+	for i, unit := range c.units {
+		y := padding + (float64(i)+0.5)*rowHeight
+
+		dc.SetColor(c.color)
+		dc.DrawStringAnchored(labels[i], padding, y, 0, 0.5)
+		dc.DrawStringAnchored(texts[i], float64(c.w)-padding, y, 1, 0.5)
+
+		c.drawBar(dc, barX, y-barHeight/2, barWidth, barHeight, float64(values[i])/float64(unit.max))
+	}
+
+	if !c.startDate.IsZero() {
+		y := padding + (float64(rows)-0.5)*rowHeight
+		progress := c.overallProgress(second)
+
+		dc.SetColor(c.color)
+		dc.DrawStringAnchored(fmt.Sprintf("%d%%", int(progress*100)), float64(c.w)-padding, y, 1, 0.5)
+
+		// The overall bar also spans the label column
+		c.drawBar(dc, padding, y-barHeight/2, barX+barWidth-padding, barHeight, progress)
+	}
+
+	return dc.Image()
+}
+
+// drawBar draws a rounded track with the leading fraction (clamped to
+// [0, 1]) filled.
+func (c *Countdown) drawBar(dc *gg.Context, x, y, w, h, fraction float64) {
+	fraction = math.Max(0, math.Min(1, fraction))
+
+	dc.SetColor(c.blendColorByAlpha(50))
+	dc.DrawRoundedRectangle(x, y, w, h, h/2)
+	dc.Fill()
+
+	if fraction == 0 {
+		return
+	}
+
+	// Keep the fill at least as wide as it is tall so its ends stay round
+	dc.SetColor(c.color)
+	dc.DrawRoundedRectangle(x, y, math.Max(h, w*fraction), h, h/2)
+	dc.Fill()
+}
+
+// overallProgress returns how much of the span from the start date to the
+// target has elapsed the given number of seconds after now.
+func (c *Countdown) overallProgress(second int) float64 {
+	now := c.now.Add(time.Duration(second) * time.Second)
+	span := c.endDate.Sub(c.startDate)
+
+	// An empty span would divide to NaN, which gg never finishes drawing
+	if span <= 0 {
+		if now.Before(c.endDate) {
+			return 0
+		}
+
+		return 1
+	}
+
+	progress := float64(now.Sub(c.startDate)) / float64(span)
+
+	return math.Max(0, math.Min(1, progress))
+}
+
+func measureWidth(dc *gg.Context, s string) float64 {
+	w, _ := dc.MeasureString(s)
+
+	return w
+}
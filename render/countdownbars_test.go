@@ -0,0 +1,36 @@
+package render
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBarsProgressBeforeCountUp(t *testing.T) {
+	c, err := NewCountdown(CountdownOptions{
+		Kind:       "bars",
+		Direction:  "up",
+		StartDate:  "2029-01-01",
+		TargetDate: "2030-01-01",
+		Now:        time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+		Frames:     1,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.overallProgress(0); got != 0 {
+		t.Errorf("overallProgress = %v, want 0", got)
+	}
+
+	// Used to hang drawing a NaN wide bar
+	if _, err := c.Frame(0); err != nil {
+		t.Fatal(err)
+	}
+
+	empty := &Countdown{startDate: c.startDate, endDate: c.startDate, now: c.now}
+
+	if got := empty.overallProgress(0); got != 1 {
+		t.Errorf("overallProgress of an empty span = %v, want 1", got)
+	}
+}
@@ -0,0 +1,158 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// sevenSegments lists the lit segments of each digit, with bits 0 to 6
+// standing for the top, top right, bottom right, bottom, bottom left, top
+// left and middle segments.
+var sevenSegments = [10]uint8{
+	0b0111111, // 0
+	0b0000110, // 1
+	0b1011011, // 2
+	0b1001111, // 3
+	0b1100110, // 4
+	0b1101101, // 5
+	0b1111101, // 6
+	0b0000111, // 7
+	0b1111111, // 8
+	0b1101111, // 9
+}
+
+// createFrameDigital draws the units as seven-segment digits separated by
+// colons that blink every other second.
+func (c *Countdown) createFrameDigital(values []int, second int) image.Image {
+	dc := gg.NewContext(c.w, c.h)
+
+	dc.SetColor(c.bg)
+	dc.Clear()
+
+	digitWidth := 55 * c.scale
+	digitHeight := 100 * c.scale
+	digitGap := 10 * c.scale
+	colonWidth := 30 * c.scale
+
+	groups := make([]string, len(values))
+	total := float64(len(values)-1) * colonWidth
+
+	for i, value := range values {
+		// A minus sign has no segments; past the target the clock stays at zero
+		groups[i] = fmt.Sprintf("%02d", max(value, 0))
+		total += float64(len(groups[i]))*(digitWidth+digitGap) - digitGap
+	}
+
+	// Wide values (e.g. hundreds of days) shrink the clock to fit
+	if fit := 0.9 * float64(c.w) / total; fit < 1 {
+		digitWidth *= fit
+		digitHeight *= fit
+		digitGap *= fit
+		colonWidth *= fit
+		total *= fit
+	}
+
+	x := (float64(c.w) - total) / 2
+	top := float64(c.h)/2 - 12*c.scale - digitHeight/2
+
+	face, err := loadFont(c.font, 16*c.scale, 0)
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			c.drawColon(dc, x, top, colonWidth, digitHeight, second%2 == 0)
+			x += colonWidth
+		}
+
+		groupWidth := float64(len(group))*(digitWidth+digitGap) - digitGap
+
+		// Draw label text
+		dc.SetColor(c.color)
+		dc.DrawStringAnchored(strings.ToUpper(c.unitLabel(c.units[i])), x+groupWidth/2, top+digitHeight+24*c.scale, 0.5, 0.5)
+
+		for _, digit := range group {
+			c.drawSevenSegment(dc, x, top, digitWidth, digitHeight, int(digit-'0'))
+			x += digitWidth + digitGap
+		}
+
+		x -= digitGap
+	}
+
+	return dc.Image()
+}
+
+// drawSevenSegment draws digit in the w x h box at (x, y), with unlit
+// segments dimmed rather than hidden. Anything but 0 to 9 is drawn unlit.
+func (c *Countdown) drawSevenSegment(dc *gg.Context, x, y, w, h float64, digit int) {
+	var lit uint8
+
+	if digit >= 0 && digit < len(sevenSegments) {
+		lit = sevenSegments[digit]
+	}
+
+	t := w * 0.18
+	gap := t * 0.15
+	mid := y + h/2
+
+	segments := [7][4]float64{
+		{x + t/2, y + t/2, x + w - t/2, y + t/2},         // top
+		{x + w - t/2, y + t/2, x + w - t/2, mid},         // top right
+		{x + w - t/2, mid, x + w - t/2, y + h - t/2},     // bottom right
+		{x + t/2, y + h - t/2, x + w - t/2, y + h - t/2}, // bottom
+		{x + t/2, mid, x + t/2, y + h - t/2},             // bottom left
+		{x + t/2, y + t/2, x + t/2, mid},                 // top left
+		{x + t/2, mid, x + w - t/2, mid},                 // middle
+	}
+
+	for i, s := range segments {
+		if lit&(1<<i) != 0 {
+			dc.SetColor(c.color)
+		} else {
+			dc.SetColor(c.blendColorByAlpha(30))
+		}
+
+		drawSegment(dc, s[0], s[1], s[2], s[3], t, gap)
+		dc.Fill()
+	}
+}
+
+// drawSegment adds a horizontal or vertical segment from (x0, y0) to
+// (x1, y1) with pointed ends, shortened by gap at both ends.
+func drawSegment(dc *gg.Context, x0, y0, x1, y1, t, gap float64) {
+	length := math.Hypot(x1-x0, y1-y0)
+	ux, uy := (x1-x0)/length, (y1-y0)/length
+
+	x0, y0 = x0+ux*gap, y0+uy*gap
+	x1, y1 = x1-ux*gap, y1-uy*gap
+
+	// Perpendicular half thickness
+	px, py := -uy*t/2, ux*t/2
+
+	dc.MoveTo(x0, y0)
+	dc.LineTo(x0+ux*t/2+px, y0+uy*t/2+py)
+	dc.LineTo(x1-ux*t/2+px, y1-uy*t/2+py)
+	dc.LineTo(x1, y1)
+	dc.LineTo(x1-ux*t/2-px, y1-uy*t/2-py)
+	dc.LineTo(x0+ux*t/2-px, y0+uy*t/2-py)
+	dc.ClosePath()
+}
+
+func (c *Countdown) drawColon(dc *gg.Context, x, y, w, h float64, lit bool) {
+	if lit {
+		dc.SetColor(c.color)
+	} else {
+		dc.SetColor(c.blendColorByAlpha(30))
+	}
+
+	radius := w * 0.2
+
+	dc.DrawCircle(x+w/2, y+h/3, radius)
+	dc.DrawCircle(x+w/2, y+2*h/3, radius)
+	dc.Fill()
+}
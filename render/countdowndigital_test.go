@@ -0,0 +1,21 @@
+package render
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigitalDrawsNegativeValues(t *testing.T) {
+	c, err := NewCountdown(CountdownOptions{
+		Kind:       "digital",
+		Now:        time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+		TargetDate: "2026-01-01",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Used to index sevenSegments with '-'-'0' and panic
+	c.createFrameDigital([]int{0, 0, 0, -1}, 0)
+}
@@ -0,0 +1,186 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// Limits for user-supplied expired images, to bound decoding work.
+const (
+	maxExpiredImageBytes = 5 << 20
+	maxExpiredImageSide  = 4096
+)
+
+// countdownExpiry holds what a finished countdown renders instead of the
+// running clock.
+type countdownExpiry struct {
+	mode  string // "zero", "text" or "image"
+	text  string
+	image image.Image
+}
+
+// newCountdownExpiry validates the Expired* options. The mode is inferred
+// from ExpiredText and ExpiredImage when Expired is empty.
+func newCountdownExpiry(opts CountdownOptions) (countdownExpiry, error) {
+	e := countdownExpiry{
+		mode: opts.Expired,
+		text: opts.ExpiredText,
+	}
+
+	if e.mode == "" {
+		switch {
+		case len(opts.ExpiredImage) > 0:
+			e.mode = "image"
+		case opts.ExpiredText != "":
+			e.mode = "text"
+		default:
+			e.mode = "zero"
+		}
+	}
+
+	switch e.mode {
+	case "zero", "text":
+	case "image":
+		img, err := decodeExpiredImage(opts.ExpiredImage)
+
+		if err != nil {
+			return e, &OptionError{Field: "expiredImage", Err: err}
+		}
+
+		e.image = img
+	default:
+		return e, &OptionError{Field: "expired", Err: fmt.Errorf("unknown mode %q", e.mode)}
+	}
+
+	return e, nil
+}
+
+func decodeExpiredImage(b []byte) (image.Image, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("missing image")
+	}
+
+	if len(b) > maxExpiredImageBytes {
+		return nil, fmt.Errorf("image exceeds %d bytes", maxExpiredImageBytes)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(b))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Width > maxExpiredImageSide || config.Height > maxExpiredImageSide {
+		return nil, fmt.Errorf("image exceeds %dx%d pixels", maxExpiredImageSide, maxExpiredImageSide)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(b))
+
+	return img, err
+}
+
+// decodeBase64Param decodes a base64 string or data URL as passed in the
+// expiredImage parameter.
+func decodeBase64Param(s string) ([]byte, error) {
+	if i := strings.Index(s, ";base64,"); strings.HasPrefix(s, "data:") && i >= 0 {
+		s = s[i+len(";base64,"):]
+	}
+
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+func (c *Countdown) expiredPalette() color.Palette {
+	if c.expiry.mode == "image" {
+		return palette.Plan9
+	}
+
+	return blendPalette(c.color, c.bg)
+}
+
+// createFrameExpired draws the expiry message or image, or returns nil when
+// the zero clock should be drawn instead.
+func (c *Countdown) createFrameExpired() image.Image {
+	switch c.expiry.mode {
+	case "text":
+		return c.createFrameText()
+	case "image":
+		return c.createFrameImage()
+	}
+
+	return nil
+}
+
+func (c *Countdown) createFrameText() image.Image {
+	dc := gg.NewContext(c.w, c.h)
+
+	dc.SetColor(c.bg)
+	dc.Clear()
+
+	text := c.expiry.text
+
+	if text == "" {
+		text = c.getTranslation("ended")
+	}
+
+	text = shapeText(text)
+
+	// Shrink the message until it fits the canvas with some padding
+	maxWidth := float64(c.w) * 0.9
+
+	size := 60 * c.scale
+
+	for next := size; next >= 8; next *= 0.9 {
+		face, err := measureFont(c.font, next, 0)
+
+		if err != nil {
+			break
+		}
+
+		size = next
+		dc.SetFontFace(face)
+
+		if width, _ := dc.MeasureString(text); width <= maxWidth {
+			break
+		}
+	}
+
+	if face, err := loadFont(c.font, size, 0); err == nil {
+		dc.SetFontFace(face)
+	}
+
+	dc.SetColor(c.color)
+	dc.DrawStringAnchored(text, float64(c.w)/2, float64(c.h)/2, 0.5, 0.5)
+
+	return dc.Image()
+}
+
+func (c *Countdown) createFrameImage() image.Image {
+	dc := gg.NewContext(c.w, c.h)
+
+	dc.SetColor(c.bg)
+	dc.Clear()
+
+	// Scale the image to fit the canvas, keeping its aspect ratio
+	bounds := c.expiry.image.Bounds()
+	scale := min(float64(c.w)/float64(bounds.Dx()), float64(c.h)/float64(bounds.Dy()))
+
+	dc.Translate(float64(c.w)/2, float64(c.h)/2)
+	dc.Scale(scale, scale)
+	dc.DrawImageAnchored(c.expiry.image, 0, 0, 0.5, 0.5)
+
+	return dc.Image()
+}
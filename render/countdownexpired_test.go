@@ -0,0 +1,68 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCountdownExpiresDuringAnimation(t *testing.T) {
+	now := time.Date(2025, 12, 1, 10, 20, 30, 0, time.UTC)
+
+	for _, mode := range []string{"zero", "text"} {
+		newCountdown := func(now time.Time, frames int) *Countdown {
+			c, err := NewCountdown(CountdownOptions{
+				Background:        "#000000",
+				Color:             "#ffffff",
+				Expired:           mode,
+				ExpiredBackground: "#ffffff",
+				ExpiredColor:      "#cc0000",
+				Frames:            frames,
+				Kind:              "basic",
+				Now:               now,
+				TargetDate:        "2025-12-01T10:20:32Z",
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return c
+		}
+
+		running := newCountdown(now, 4)
+		finished := newCountdown(now.Add(2*time.Second), 1)
+
+		if got := running.valuesAt(3); !reflect.DeepEqual(got, []int{0, 0, 0, 0}) {
+			t.Errorf("%s: values a second past the target = %v, want zeros", mode, got)
+		}
+
+		want, err := finished.Frame(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 2; i < 4; i++ {
+			got, err := running.Frame(i)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got.(*image.RGBA).Pix, want.(*image.RGBA).Pix) {
+				t.Errorf("%s: frame %d differs from the expired frame", mode, i)
+			}
+
+			if !reflect.DeepEqual(running.Palette(i), finished.Palette(0)) {
+				t.Errorf("%s: frame %d does not use the expired palette", mode, i)
+			}
+		}
+
+		if reflect.DeepEqual(running.Palette(1), finished.Palette(0)) {
+			t.Errorf("%s: frame 1 uses the expired palette before the target", mode)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// The flip kind renders each second as flipSteps frames: the first ones
+// fold the changed cards over flipStepDelay hundredths of a second each and
+// the last one holds the settled clock for the rest of the second.
+const (
+	flipSteps     = 5
+	flipStepDelay = 5
+)
+
+// steps returns how many GIF frames make up one second of the countdown.
+func (c *Countdown) steps() int {
+	if c.kind != "flip" || c.frames == 1 {
+		return 1
+	}
+
+	return flipSteps
+}
+
+// createFrameFlip draws split-flap cards moving from prev to values, with
+// progress going from 0 (prev) to 1 (values).
+func (c *Countdown) createFrameFlip(prev, values []int, progress float64) image.Image {
+	dc := gg.NewContext(c.w, c.h)
+
+	dc.SetColor(c.bg)
+	dc.Clear()
+
+	spacing := 160 * c.scale
+	y := float64(c.h) / 2
+
+	for i, unit := range c.units {
+		x := c.unitX(i, spacing)
+
+		c.drawFlipUnit(dc, x, y, prev[i], values[i], progress, c.unitLabel(unit))
+	}
+
+	return dc.Image()
+}
+
+func (c *Countdown) drawFlipUnit(dc *gg.Context, x, y float64, prev, value int, progress float64, label string) {
+	n := len(fmt.Sprintf("%02d", max(prev, value)))
+	from := fmt.Sprintf("%0*d", n, prev)
+	to := fmt.Sprintf("%0*d", n, value)
+
+	gap := 6 * c.scale
+	cardWidth := math.Min(62*c.scale, (140*c.scale-gap*float64(n-1))/float64(n))
+	cardHeight := 96 * c.scale
+	left := x - (float64(n)*cardWidth+float64(n-1)*gap)/2
+	top := y - 20*c.scale - cardHeight/2
+
+	cards := map[byte]image.Image{}
+	card := func(digit byte) image.Image {
+		if _, ok := cards[digit]; !ok {
+			cards[digit] = c.drawFlipCard(digit, int(cardWidth), int(cardHeight))
+		}
+
+		return cards[digit]
+	}
+
+	for j := 0; j < n; j++ {
+		cx := int(math.Round(left + float64(j)*(cardWidth+gap)))
+		cy := int(math.Round(top))
+
+		if from[j] == to[j] || progress >= 1 {
+			dc.DrawImage(card(to[j]), cx, cy)
+		} else {
+			c.drawFlipFold(dc, card(from[j]), card(to[j]), cx, cy, progress)
+		}
+
+		// Hinge between the two halves
+		dc.SetColor(c.bg)
+		dc.SetLineWidth(2 * c.scale)
+		dc.DrawLine(float64(cx), float64(cy)+cardHeight/2, float64(cx)+cardWidth, float64(cy)+cardHeight/2)
+		dc.Stroke()
+	}
+
+	// Draw label text
+	face, err := loadFont(c.font, 16*c.scale, 0)
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+	dc.SetColor(c.color)
+	dc.DrawStringAnchored(strings.ToUpper(label), x, y+52*c.scale, 0.5, 0.5)
+}
+
+// drawFlipCard renders a single card showing digit.
+func (c *Countdown) drawFlipCard(digit byte, w, h int) image.Image {
+	dc := gg.NewContext(w, h)
+
+	dc.SetColor(c.blendColorByAlpha(40))
+	dc.DrawRoundedRectangle(0, 0, float64(w), float64(h), 6*c.scale)
+	dc.Fill()
+
+	face, err := loadFont(c.font, float64(w)*1.1, 0)
+	if err == nil {
+		dc.SetFontFace(face)
+	}
+	dc.SetColor(c.color)
+	dc.DrawStringAnchored(string(digit), float64(w)/2, float64(h)/2, 0.5, 0.5)
+
+	return dc.Image()
+}
+
+// drawFlipFold draws a card halfway through flipping from prev to next. The
+// top half of next and the bottom half of prev stay still while a flap
+// folds down over the hinge: first the top of prev shrinking towards it,
+// then the bottom of next growing out of it.
+func (c *Countdown) drawFlipFold(dc *gg.Context, prev, next image.Image, x, y int, progress float64) {
+	b := next.Bounds()
+	half := b.Dy() / 2
+	topHalf := image.Rect(0, 0, b.Dx(), half)
+	bottomHalf := image.Rect(0, half, b.Dx(), b.Dy())
+
+	// Sub images keep their coordinates, so the bottom halves land below
+	// the hinge when drawn at the card origin.
+	dc.DrawImage(subImage(next, topHalf), x, y)
+	dc.DrawImage(subImage(prev, bottomHalf), x, y)
+
+	flap, scale := subImage(prev, topHalf), 1-2*progress
+
+	if progress >= 0.5 {
+		flap, scale = subImage(next, bottomHalf), 2*progress-1
+	}
+
+	if scale < 0.02 {
+		return
+	}
+
+	dc.Push()
+	dc.Translate(float64(x), float64(y+half))
+	dc.Scale(1, scale)
+	dc.DrawImage(flap, 0, -half)
+	dc.Pop()
+}
+
+func subImage(img image.Image, r image.Rectangle) image.Image {
+	return img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}).SubImage(r)
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type countdownUnit struct {
+	name     string
+	suffix   string
+	duration time.Duration
+	max      int
+}
+
+// countdownUnits lists every unit a Countdown can show, largest first.
+var countdownUnits = []countdownUnit{
+	{"weeks", "w", 7 * 24 * time.Hour, 52},
+	{"days", "d", 24 * time.Hour, 31},
+	{"hours", "h", time.Hour, 24},
+	{"minutes", "m", time.Minute, 60},
+	{"seconds", "s", time.Second, 60},
+}
+
+var defaultCountdownUnits = []string{"days", "hours", "minutes", "seconds"}
+
+// parseUnits validates names against countdownUnits and returns the matching
+// units largest first, without duplicates. An empty list selects the default
+// days, hours, minutes and seconds.
+func parseUnits(names []string) ([]countdownUnit, error) {
+	if len(names) == 0 {
+		names = defaultCountdownUnits
+	}
+
+	selected := map[string]bool{}
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "" {
+			continue
+		}
+
+		found := false
+
+		for _, unit := range countdownUnits {
+			if unit.name == name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, &OptionError{Field: "units", Err: fmt.Errorf("unknown unit %q", name)}
+		}
+
+		selected[name] = true
+	}
+
+	if len(selected) == 0 {
+		return parseUnits(defaultCountdownUnits)
+	}
+
+	var units []countdownUnit
+
+	for _, unit := range countdownUnits {
+		if !selected[unit.name] {
+			continue
+		}
+
+		// Days only count up to a week when weeks are shown
+		if unit.name == "days" && selected["weeks"] {
+			unit.max = 7
+		}
+
+		units = append(units, unit)
+	}
+
+	return units, nil
+}
+
+// splitDuration breaks d into the given units. Omitted larger units roll into
+// the largest unit shown (50 hours when days are hidden) and omitted units in
+// between roll into the next smaller one shown.
+func splitDuration(d time.Duration, units []countdownUnit) []int {
+	remaining := time.Duration(int64(d.Seconds())) * time.Second
+	values := make([]int, len(units))
+
+	for i, unit := range units {
+		values[i] = int(remaining / unit.duration)
+		remaining -= time.Duration(values[i]) * unit.duration
+	}
+
+	return values
+}
@@ -0,0 +1,45 @@
+package render
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitDuration(t *testing.T) {
+	d := 9*24*time.Hour + 2*time.Hour + 3*time.Minute + 4*time.Second + 500*time.Millisecond
+
+	tests := []struct {
+		units []string
+		want  []int
+	}{
+		{nil, []int{9, 2, 3, 4}},
+		{[]string{"weeks", "days", "hours"}, []int{1, 2, 2}},
+		{[]string{"hours", "minutes", "seconds"}, []int{218, 3, 4}},
+		{[]string{"seconds", "days"}, []int{9, 7384}},
+		{[]string{"weeks", "minutes"}, []int{1, 2*24*60 + 2*60 + 3}},
+	}
+
+	for _, tt := range tests {
+		units, err := parseUnits(tt.units)
+
+		if err != nil {
+			t.Fatalf("parseUnits(%v) failed: %v", tt.units, err)
+		}
+
+		if got := splitDuration(d, units); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitDuration(%v) = %v, want %v", tt.units, got, tt.want)
+		}
+	}
+}
+
+func TestParseUnitsRejectsUnknown(t *testing.T) {
+	_, err := parseUnits([]string{"days", "fortnights"})
+
+	var optErr *OptionError
+
+	if !errors.As(err, &optErr) || optErr.Field != "units" {
+		t.Fatalf("parseUnits error = %v, want *OptionError for units", err)
+	}
+}
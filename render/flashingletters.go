@@ -0,0 +1,161 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+type FlashingLetters struct {
+	bg               color.Color
+	color            color.Color
+	delay            float64
+	font             string
+	fontFace         font.Face
+	frames           int
+	height           int
+	seed             int64
+	text             string
+	width            int
+	flashProbability float64
+}
+
+type FlashingLettersOptions struct {
+	Background       string
+	Color            string
+	Delay            float64
+	Font             string
+	Frames           int
+	Height           int
+	Seed             int64 // drives which letters flash; zero derives it from the options
+	Text             string
+	Width            int
+	FlashProbability float64
+}
+
+func NewFlashingLetters(opts FlashingLettersOptions) (*FlashingLetters, error) {
+	if opts.Frames < 1 {
+		opts.Frames = 1
+	} else if opts.Frames > 60 {
+		opts.Frames = 60
+	}
+
+	opts.Width = clampCanvasSide(opts.Width, 400)
+	opts.Height = clampCanvasSide(opts.Height, 200)
+
+	if opts.FlashProbability < 0 {
+		opts.FlashProbability = 0
+	} else if opts.FlashProbability > 1 {
+		opts.FlashProbability = 1
+	}
+
+	fontName, err := resolveFont(opts.Font)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bg, fg := parseHexString(opts.Background), parseHexString(opts.Color)
+
+	if opts.Seed == 0 {
+		opts.Seed = seedFromOptions(opts.Text, opts.Frames, opts.Width, opts.Height, opts.Delay, bg, fg, fontName, opts.FlashProbability)
+	}
+
+	// Create font face
+	fontFace, err := loadFont(fontName, float64(opts.Height)*0.6, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FlashingLetters{
+		bg:               bg,
+		color:            fg,
+		delay:            opts.Delay,
+		font:             fontName,
+		fontFace:         fontFace,
+		frames:           opts.Frames,
+		height:           opts.Height,
+		seed:             opts.Seed,
+		text:             opts.Text,
+		width:            opts.Width,
+		flashProbability: opts.FlashProbability,
+	}, nil
+}
+
+func init() {
+	Register("flashing-letters", func(p Params) (Renderer, error) {
+		flasher, err := NewFlashingLetters(FlashingLettersOptions{
+			Background:       p.String("background", p.String("bg", "#000000")),
+			Color:            p.String("color", "#ffffff"),
+			Delay:            p.Float("delay", 100),
+			Font:             p.String("font", ""),
+			Frames:           p.Int("frames", 20),
+			Height:           p.Int("height", 200),
+			Seed:             int64(p.Int("seed", 0)),
+			Text:             p.String("text", "SALE"),
+			Width:            p.Int("width", 400),
+			FlashProbability: p.Float("flashProbability", 0.3),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return flasher, nil
+	})
+}
+
+func (f *FlashingLetters) Create() ([]byte, error) {
+	return Encode(f)
+}
+
+func (f *FlashingLetters) Frames() int {
+	return f.frames
+}
+
+func (f *FlashingLetters) Delay(i int) int {
+	return int(f.delay / 10)
+}
+
+func (f *FlashingLetters) Palette(i int) color.Palette {
+	return blendPalette(f.color, f.bg)
+}
+
+func (f *FlashingLetters) Frame(i int) (image.Image, error) {
+	dc := gg.NewContext(f.width, f.height)
+
+	// Set background
+	dc.SetColor(f.bg)
+	dc.Clear()
+
+	dc.SetFontFace(f.fontFace)
+
+	// Calculate text position
+	textWidth, textHeight := dc.MeasureString(f.text)
+	x := (float64(f.width) - textWidth) / 2
+	y := (float64(f.height) + textHeight) / 2
+
+	// Each frame gets its own generator so frames can render in any order.
+	// Hashing keeps frame i of one seed apart from frame i-1 of the next.
+	rng := rand.New(rand.NewSource(seedFromOptions(f.seed, i)))
+
+	// Draw each character with random flashing
+	for _, char := range f.text {
+		charWidth, _ := dc.MeasureString(string(char))
+
+		// Randomly decide if this character should flash
+		if rng.Float64() < f.flashProbability {
+			dc.SetColor(f.bg) // Make character disappear
+		} else {
+			dc.SetColor(f.color)
+		}
+
+		dc.DrawString(string(char), x, y)
+		x += charWidth
+	}
+
+	return dc.Image(), nil
+}
@@ -0,0 +1,39 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"testing"
+)
+
+func TestFlashingLettersConsecutiveSeedsDiffer(t *testing.T) {
+	frame := func(seed int64, i int) []byte {
+		f, err := NewFlashingLetters(FlashingLettersOptions{
+			Background:       "#000000",
+			Color:            "#ffffff",
+			Frames:           2,
+			Height:           100,
+			Seed:             seed,
+			Text:             "BLACK FRIDAY SALE",
+			Width:            600,
+			FlashProbability: 0.5,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		img, err := f.Frame(i)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return img.(*image.RGBA).Pix
+	}
+
+	// Frame 1 of seed 7 used to be frame 0 of seed 8
+	if bytes.Equal(frame(7, 1), frame(8, 0)) {
+		t.Error("seed 8 repeats seed 7 shifted by one frame")
+	}
+}
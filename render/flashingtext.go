@@ -0,0 +1,195 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+
+	"github.com/fogleman/gg"
+)
+
+type FlashingText struct {
+	bg     color.Color
+	color  color.Color
+	delay  float64
+	font   string
+	frames int
+	height int
+	seed   int64
+	text   string
+	width  int
+	words  int
+
+	positions []WordPosition
+}
+
+type FlashingTextOptions struct {
+	Background string
+	Color      string
+	Delay      float64
+	Font       string
+	Frames     int
+	Height     int
+	Seed       int64 // drives the word positions; zero derives it from the options
+	Text       string
+	Width      int
+	Words      int
+}
+
+type WordPosition struct {
+	x    float64
+	y    float64
+	size float64
+	word string
+}
+
+func NewFlashingText(opts FlashingTextOptions) (*FlashingText, error) {
+	if opts.Frames < 1 {
+		opts.Frames = 1
+	} else if opts.Frames > 60 {
+		opts.Frames = 60
+	}
+
+	opts.Width = clampCanvasSide(opts.Width, 600)
+	opts.Height = clampCanvasSide(opts.Height, 400)
+
+	if opts.Words < 1 {
+		opts.Words = 1
+	} else if opts.Words > 20 {
+		opts.Words = 20
+	}
+
+	fontName, err := resolveFont(opts.Font)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bg, fg := parseHexString(opts.Background), parseHexString(opts.Color)
+
+	if opts.Seed == 0 {
+		opts.Seed = seedFromOptions(opts.Text, opts.Frames, opts.Words, opts.Width, opts.Height, opts.Delay, bg, fg, fontName)
+	}
+
+	f := &FlashingText{
+		bg:     bg,
+		color:  fg,
+		delay:  opts.Delay,
+		font:   fontName,
+		frames: opts.Frames,
+		height: opts.Height,
+		seed:   opts.Seed,
+		text:   opts.Text,
+		width:  opts.Width,
+		words:  opts.Words,
+	}
+
+	// Generate random positions for words
+	f.positions = f.generateWordPositions()
+
+	return f, nil
+}
+
+func init() {
+	Register("flashing-text", func(p Params) (Renderer, error) {
+		flasher, err := NewFlashingText(FlashingTextOptions{
+			Background: p.String("background", p.String("bg", "#000000")),
+			Color:      p.String("color", "#ffffff"),
+			Delay:      p.Float("delay", 300),
+			Font:       p.String("font", ""),
+			Frames:     p.Int("frames", 30),
+			Height:     p.Int("height", 400),
+			Seed:       int64(p.Int("seed", 0)),
+			Text:       p.String("text", "SALE"),
+			Width:      p.Int("width", 600),
+			Words:      p.Int("words", 10),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return flasher, nil
+	})
+}
+
+func (f *FlashingText) Create() ([]byte, error) {
+	return Encode(f)
+}
+
+func (f *FlashingText) Frames() int {
+	return f.frames
+}
+
+func (f *FlashingText) Delay(i int) int {
+	return int(f.delay / 10)
+}
+
+func (f *FlashingText) Palette(i int) color.Palette {
+	return blendPalette(f.color, f.bg)
+}
+
+func (f *FlashingText) generateWordPositions() []WordPosition {
+	var positions []WordPosition
+	text := f.text
+	padding := float64(f.height) * 0.1 // 10% padding
+
+	// Use the seed for positions that are predictable per options
+	rng := rand.New(rand.NewSource(f.seed))
+
+	for i := 0; i < f.words; i++ {
+		size := float64(f.height) * 0.15 // Random size between 15%
+
+		// Random position with padding to avoid edge cutting
+		x := padding + rng.Float64()*(float64(f.width)-2*padding-size)
+		y := padding + rng.Float64()*(float64(f.height)-2*padding-size)
+
+		// Ensure the word does not go outside the frame
+		if x+size > float64(f.width)-padding {
+			x = float64(f.width) - padding - size
+		}
+
+		if y+size > float64(f.height)-padding {
+			y = float64(f.height) - padding - size
+		}
+
+		positions = append(positions, WordPosition{
+			x:    x,
+			y:    y,
+			size: size,
+			word: text,
+		})
+	}
+
+	return positions
+}
+
+func (f *FlashingText) Frame(frameNum int) (image.Image, error) {
+	positions := f.positions
+	dc := gg.NewContext(f.width, f.height)
+
+	// Set background
+	dc.SetColor(f.bg)
+	dc.Clear()
+
+	// Select which word will be visible in this frame
+	visibleWord := frameNum % len(positions)
+
+	// Draw each word
+	for i, pos := range positions {
+		// Load font with the random size for this word
+		fontFace, err := loadFont(f.font, pos.size, 0)
+		if err != nil {
+			continue
+		}
+		dc.SetFontFace(fontFace)
+
+		// Only show the selected word for this frame
+		if i == visibleWord {
+			dc.SetColor(f.color)
+			dc.DrawStringAnchored(pos.word, pos.x, pos.y, 0.5, 0.5)
+		}
+	}
+
+	return dc.Image(), nil
+}
@@ -0,0 +1,30 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDefaultSeedIgnoresExplicitDefaultFont(t *testing.T) {
+	for _, name := range []string{"flashing-text", "flashing-letters"} {
+		build := func(font string) []byte {
+			b, err := Build(name, StringParams(func(key string) string {
+				if key == "font" {
+					return font
+				}
+
+				return ""
+			}))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return b
+		}
+
+		if !bytes.Equal(build(""), build(defaultFont)) {
+			t.Errorf("%s: font=%s renders differently from the default font", name, defaultFont)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// fallbackFonts are the embedded fonts/fallback/*.ttf subsets, in file name
+// order, that end every fallback chain. They cover the scripts of the
+// countdown translations that the selectable fonts lack; the README in that
+// directory lists their sources and how they are built.
+var fallbackFonts = embeddedFallbacks()
+
+// fontFallbacks holds the fonts set with SetFontFallbacks, tried before
+// fallbackFonts. Guarded by fontCacheMu.
+var fontFallbacks = map[string][]string{}
+
+func embeddedFallbacks() []string {
+	var names []string
+	paths, _ := fs.Glob(fontFS, "fonts/fallback/*.ttf")
+
+	for _, p := range paths {
+		names = append(names, "fallback/"+strings.TrimSuffix(path.Base(p), ".ttf"))
+	}
+
+	return names
+}
+
+// SetFontFallbacks sets the embedded or registered fonts that draw the runes
+// font name has no glyph for, in order, ahead of the embedded script
+// fallbacks.
+func SetFontFallbacks(name string, fallbacks ...string) error {
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	for _, n := range append([]string{name}, fallbacks...) {
+		if !ALLOWED_FONTS[n] && !userFonts[n] {
+			return &OptionError{Field: "font", Err: fmt.Errorf("unknown font %q", n)}
+		}
+	}
+
+	fontFallbacks[name] = fallbacks
+	faceCache.clear()
+
+	return nil
+}
+
+// fallbackChain returns name followed by the fonts to try for the runes it
+// lacks. fontCacheMu must be held.
+func fallbackChain(name string) []string {
+	chain := []string{name}
+
+	for _, n := range append(fontFallbacks[name], fallbackFonts...) {
+		if n != name {
+			chain = append(chain, n)
+		}
+	}
+
+	return chain
+}
+
+// fallbackFace draws each rune with the first of fonts that has a glyph for
+// it, falling back to the first font (and its missing glyph box) when none
+// has. Metrics are those of the first font. Faces of the fallback fonts are
+// only created once a rune needs them.
+type fallbackFace struct {
+	opts  *truetype.Options
+	fonts []*truetype.Font
+
+	mu    sync.Mutex
+	faces []font.Face
+}
+
+// newFallbackFace returns a face of font name and its fallback chain.
+// fontCacheMu must be held.
+func newFallbackFace(name string, opts *truetype.Options) (*fallbackFace, error) {
+	f := &fallbackFace{opts: opts}
+
+	for i, n := range fallbackChain(name) {
+		ft, err := parseFont(n)
+
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+
+			continue
+		}
+
+		f.fonts = append(f.fonts, ft)
+	}
+
+	f.faces = make([]font.Face, len(f.fonts))
+
+	return f, nil
+}
+
+func (f *fallbackFace) face(r rune) font.Face {
+	i := 0
+
+	for j, ft := range f.fonts {
+		if ft.Index(r) != 0 {
+			i = j
+			break
+		}
+	}
+
+	return f.faceAt(i)
+}
+
+func (f *fallbackFace) faceAt(i int) font.Face {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.faces[i] == nil {
+		f.faces[i] = &syncFace{face: truetype.NewFace(f.fonts[i], f.opts)}
+	}
+
+	return f.faces[i]
+}
+
+func (f *fallbackFace) Close() error {
+	return nil
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return f.face(r).Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	return f.face(r).GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	return f.face(r).GlyphAdvance(r)
+}
+
+// Kern only applies between runes drawn with the same font.
+func (f *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	face := f.face(r0)
+
+	if face != f.face(r1) {
+		return 0
+	}
+
+	return face.Kern(r0, r1)
+}
+
+func (f *fallbackFace) Metrics() font.Metrics {
+	return f.faceAt(0).Metrics()
+}
@@ -0,0 +1,331 @@
+package render
+
+import (
+	"container/list"
+	"embed"
+	"fmt"
+	"image"
+	"image/draw"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+//go:embed fonts/*.ttf fonts/fallback/*.ttf
+var fontFS embed.FS
+
+// ALLOWED_FONTS holds the names accepted by the font option: the base names
+// of the embedded fonts/*.ttf files.
+var ALLOWED_FONTS = embeddedFonts()
+
+const defaultFont = "impact"
+
+// Limits for fonts registered at runtime, to bound parsing work and the
+// memory held by the font caches.
+const (
+	maxFontBytes = 10 << 20
+	maxUserFonts = 32
+)
+
+var fontNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+func embeddedFonts() map[string]bool {
+	names := map[string]bool{}
+	paths, _ := fs.Glob(fontFS, "fonts/*.ttf")
+
+	for _, p := range paths {
+		names[strings.TrimSuffix(path.Base(p), ".ttf")] = true
+	}
+
+	return names
+}
+
+// Fonts returns the names accepted by the font option, embedded and
+// registered ones, sorted.
+func Fonts() []string {
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	names := make([]string, 0, len(ALLOWED_FONTS)+len(userFonts))
+
+	for name := range ALLOWED_FONTS {
+		names = append(names, name)
+	}
+
+	for name := range userFonts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// resolveFont validates a font option, an empty name selecting the default.
+func resolveFont(name string) (string, error) {
+	if name == "" {
+		return defaultFont, nil
+	}
+
+	if !fontExists(name) {
+		return "", &OptionError{Field: "font", Err: fmt.Errorf("unknown font %q, want one of %s", name, strings.Join(Fonts(), ", "))}
+	}
+
+	return name, nil
+}
+
+func fontExists(name string) bool {
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	return ALLOWED_FONTS[name] || userFonts[name]
+}
+
+// RegisterFont makes a TrueType font (.ttf, or .otf with TrueType outlines)
+// available to the font option under name, which must be lower case letters,
+// digits, '-' or '_' and may not shadow an embedded font. Registering a name
+// again replaces the font.
+func RegisterFont(name string, data []byte) error {
+	if !fontNamePattern.MatchString(name) {
+		return &OptionError{Field: "font", Err: fmt.Errorf("invalid font name %q", name)}
+	}
+
+	if ALLOWED_FONTS[name] {
+		return &OptionError{Field: "font", Err: fmt.Errorf("%q is an embedded font", name)}
+	}
+
+	if len(data) > maxFontBytes {
+		return &OptionError{Field: "font", Err: fmt.Errorf("font is %d bytes, more than the %d allowed", len(data), maxFontBytes)}
+	}
+
+	f, err := truetype.Parse(data)
+
+	if err != nil {
+		return &OptionError{Field: "font", Err: fmt.Errorf("failed to parse font: %v", err)}
+	}
+
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	if !userFonts[name] && len(userFonts) >= maxUserFonts {
+		return &OptionError{Field: "font", Err: fmt.Errorf("more than %d fonts registered", maxUserFonts)}
+	}
+
+	userFonts[name] = true
+	parsedFonts[name] = f
+
+	// The font may also be in other fonts' fallback chains
+	faceCache.clear()
+
+	return nil
+}
+
+type faceKey struct {
+	name      string
+	size, dpi float64
+}
+
+// Parsed fonts are cached for the lifetime of the process, so rendering a
+// frame never re-reads or re-parses a font file. Sized faces are kept in a
+// small least recently used cache: each one holds glyph masks, and sizes
+// vary per request.
+var (
+	fontCacheMu sync.Mutex
+	parsedFonts = map[string]*truetype.Font{}
+	faceCache   = newFaceLRU(maxCachedFaces)
+	userFonts   = map[string]bool{}
+)
+
+const (
+	maxCachedFaces = 32
+
+	// glyphCacheEntries bounds the glyph masks each truetype face
+	// allocates up front (512 by default, several MB at large sizes). The
+	// frames of a GIF reuse a few dozen glyphs at most.
+	glyphCacheEntries = 32
+)
+
+// loadFont returns a face of the embedded or registered font name, falling
+// back to impact for unknown fonts, that draws runes the font lacks with its
+// fallback chain. A zero dpi uses the truetype default of 72. Faces are
+// shared and safe for concurrent use.
+func loadFont(name string, size, dpi float64) (font.Face, error) {
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	if !ALLOWED_FONTS[name] && !userFonts[name] {
+		name = defaultFont
+	}
+
+	key := faceKey{name, size, dpi}
+
+	if face, ok := faceCache.get(key); ok {
+		return face, nil
+	}
+
+	face, err := newFallbackFace(name, &truetype.Options{
+		Size:              size,
+		DPI:               dpi,
+		GlyphCacheEntries: glyphCacheEntries,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	faceCache.add(key, face)
+
+	return face, nil
+}
+
+// measureFont returns an uncached face of font name for measuring text
+// while searching for the size that fits, so that the sizes tried are not
+// kept around.
+func measureFont(name string, size, dpi float64) (font.Face, error) {
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	if !ALLOWED_FONTS[name] && !userFonts[name] {
+		name = defaultFont
+	}
+
+	return newFallbackFace(name, &truetype.Options{
+		Size:              size,
+		DPI:               dpi,
+		GlyphCacheEntries: 1,
+	})
+}
+
+// faceLRU is a fixed size cache of faces, evicting the least recently used
+// one. fontCacheMu must be held.
+type faceLRU struct {
+	max   int
+	order *list.List
+	items map[faceKey]*list.Element
+}
+
+type faceEntry struct {
+	key  faceKey
+	face font.Face
+}
+
+func newFaceLRU(max int) *faceLRU {
+	return &faceLRU{
+		max:   max,
+		order: list.New(),
+		items: map[faceKey]*list.Element{},
+	}
+}
+
+func (c *faceLRU) get(key faceKey) (font.Face, bool) {
+	e, ok := c.items[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+
+	return e.Value.(*faceEntry).face, true
+}
+
+func (c *faceLRU) add(key faceKey, face font.Face) {
+	c.items[key] = c.order.PushFront(&faceEntry{key, face})
+
+	if c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*faceEntry).key)
+	}
+}
+
+func (c *faceLRU) clear() {
+	c.order.Init()
+	clear(c.items)
+}
+
+// parseFont returns the parsed font name; registered fonts are parsed up
+// front by RegisterFont. fontCacheMu must be held.
+func parseFont(name string) (*truetype.Font, error) {
+	if f, ok := parsedFonts[name]; ok {
+		return f, nil
+	}
+
+	fontBytes, err := fontFS.ReadFile("fonts/" + name + ".ttf")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded font file: %v", err)
+	}
+
+	f, err := truetype.Parse(fontBytes)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %v", err)
+	}
+
+	parsedFonts[name] = f
+
+	return f, nil
+}
+
+// syncFace serializes access to a font.Face. Glyph masks are copied because
+// truetype faces return views into a glyph cache that later calls overwrite.
+type syncFace struct {
+	mu   sync.Mutex
+	face font.Face
+}
+
+func (s *syncFace) Close() error {
+	return nil
+}
+
+func (s *syncFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dr, mask, maskp, advance, ok = s.face.Glyph(dot, r)
+
+	if !ok {
+		return
+	}
+
+	alpha := image.NewAlpha(image.Rect(0, 0, dr.Dx(), dr.Dy()))
+	draw.Draw(alpha, alpha.Rect, mask, maskp, draw.Src)
+
+	return dr, alpha, image.Point{}, advance, true
+}
+
+func (s *syncFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.face.GlyphBounds(r)
+}
+
+func (s *syncFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.face.GlyphAdvance(r)
+}
+
+func (s *syncFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.face.Kern(r0, r1)
+}
+
+func (s *syncFace) Metrics() font.Metrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.face.Metrics()
+}
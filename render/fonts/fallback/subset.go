@@ -0,0 +1,670 @@
+//go:build ignore
+
+// Subset writes a copy of a TrueType font keeping only the glyphs of the
+// given Unicode ranges and characters, which is how the fallback fonts in
+// this directory are kept small enough to embed in the WebAssembly build.
+//
+// Usage:
+//
+//	go run subset.go -ranges 0590-05FF,0600-06FF -o out.ttf in.ttf
+//	go run subset.go -text "週日時間" -index 2 -o out.ttf in.ttc
+//
+// Layout tables (GSUB, GPOS, kern) are dropped; hinting tables and the name
+// table, which carries the copyright and license, are kept as is. Fonts with
+// CFF outlines, which the truetype package cannot read, get their cubic
+// curves converted to TrueType quadratic ones. -index selects a font of a
+// collection (.ttc).
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// keptTables are copied unchanged; the glyph tables are rebuilt.
+var keptTables = []string{"OS/2", "cvt ", "fpgm", "gasp", "name", "prep"}
+
+func main() {
+	ranges := flag.String("ranges", "", "comma separated hex code point ranges, e.g. 0600-06FF,FB50-FDFF")
+	text := flag.String("text", "", "characters to keep, in addition to -ranges")
+	index := flag.Int("index", 0, "font to subset in a collection")
+	output := flag.String("o", "", "output file")
+	flag.Parse()
+
+	if flag.NArg() != 1 || *ranges == "" && *text == "" || *output == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := os.ReadFile(flag.Arg(0))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var runes []rune
+
+	if *ranges != "" {
+		if runes, err = parseRanges(*ranges); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	for _, r := range *text {
+		if r != ' ' {
+			runes = append(runes, r)
+		}
+	}
+
+	out, err := subset(src, *index, runes)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseRanges(s string) ([]rune, error) {
+	var runes []rune
+
+	for _, part := range strings.Split(s, ",") {
+		lo, hi, _ := strings.Cut(strings.TrimSpace(part), "-")
+
+		if hi == "" {
+			hi = lo
+		}
+
+		start, err := strconv.ParseUint(lo, 16, 32)
+
+		if err != nil {
+			return nil, fmt.Errorf("bad range %q", part)
+		}
+
+		end, err := strconv.ParseUint(hi, 16, 32)
+
+		if err != nil || end < start {
+			return nil, fmt.Errorf("bad range %q", part)
+		}
+
+		for r := start; r <= end; r++ {
+			runes = append(runes, rune(r))
+		}
+	}
+
+	return runes, nil
+}
+
+func subset(src []byte, index int, runes []rune) ([]byte, error) {
+	collection, err := sfnt.ParseCollection(src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := collection.Font(index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := readTables(src, index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "post"} {
+		if tables[tag] == nil {
+			return nil, fmt.Errorf("missing %s table", tag)
+		}
+	}
+
+	head, hhea, maxp := tables["head"], tables["hhea"], tables["maxp"]
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+
+	var glyph func(gid int) []byte
+
+	switch {
+	case tables["glyf"] != nil && tables["loca"] != nil:
+		offsets := glyphOffsets(tables["loca"], numGlyphs, binary.BigEndian.Uint16(head[50:]) == 1)
+		glyph = func(gid int) []byte {
+			return tables["glyf"][offsets[gid]:offsets[gid+1]]
+		}
+	case tables["CFF "] != nil:
+		glyphs := map[int][]byte{}
+		glyph = func(gid int) []byte {
+			if _, ok := glyphs[gid]; !ok {
+				glyphs[gid], err = quadraticGlyph(f, gid)
+			}
+
+			return glyphs[gid]
+		}
+		maxp = nil
+	default:
+		return nil, fmt.Errorf("no glyf or CFF table")
+	}
+
+	var buf sfnt.Buffer
+	lookup := func(r rune) int {
+		gid, _ := f.GlyphIndex(&buf, r)
+
+		return int(gid)
+	}
+
+	// Keep .notdef first, then the mapped glyphs and the components of
+	// composite glyphs, in order of first use.
+	newID := map[int]int{}
+	var order []int
+	keep := func(gid int) {
+		if _, ok := newID[gid]; !ok {
+			newID[gid] = len(order)
+			order = append(order, gid)
+		}
+	}
+
+	keep(0)
+
+	var mapped []rune
+
+	for _, r := range runes {
+		if gid := lookup(r); gid != 0 {
+			keep(gid)
+			mapped = append(mapped, r)
+		}
+	}
+
+	for i := 0; i < len(order); i++ {
+		for _, component := range components(glyph(order[i])) {
+			keep(component)
+		}
+	}
+
+	var glyf, loca, hmtx bytes.Buffer
+
+	for _, gid := range order {
+		binary.Write(&loca, binary.BigEndian, uint32(glyf.Len()))
+
+		data := append([]byte(nil), glyph(gid)...)
+		remapComponents(data, newID)
+		glyf.Write(data)
+
+		for glyf.Len()%4 != 0 {
+			glyf.WriteByte(0)
+		}
+
+		advance, lsb := hMetric(tables["hmtx"], numHMetrics, gid)
+
+		// Converted outlines have their left side bearing at xMin
+		if tables["glyf"] == nil && len(data) >= 10 {
+			lsb = binary.BigEndian.Uint16(data[2:])
+		}
+
+		binary.Write(&hmtx, binary.BigEndian, [2]uint16{advance, lsb})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	binary.Write(&loca, binary.BigEndian, uint32(glyf.Len()))
+
+	head = append([]byte(nil), head...)
+	binary.BigEndian.PutUint32(head[8:], 0)  // checkSumAdjustment, set below
+	binary.BigEndian.PutUint16(head[50:], 1) // long loca offsets
+
+	hhea = append([]byte(nil), hhea...)
+	binary.BigEndian.PutUint16(hhea[34:], uint16(len(order)))
+
+	if maxp == nil {
+		maxp = trueTypeMaxp(glyf.Bytes(), loca.Bytes())
+	} else {
+		maxp = append([]byte(nil), maxp...)
+	}
+
+	binary.BigEndian.PutUint16(maxp[4:], uint16(len(order)))
+
+	// post version 3 has no glyph names
+	post := append([]byte(nil), tables["post"][:32]...)
+	binary.BigEndian.PutUint32(post[0:], 0x00030000)
+
+	out := map[string][]byte{
+		"cmap": buildCmap(mapped, func(r rune) int { return newID[lookup(r)] }),
+		"glyf": glyf.Bytes(),
+		"head": head,
+		"hhea": hhea,
+		"hmtx": hmtx.Bytes(),
+		"loca": loca.Bytes(),
+		"maxp": maxp,
+		"post": post,
+	}
+
+	for _, tag := range keptTables {
+		if tables[tag] != nil {
+			out[tag] = tables[tag]
+		}
+	}
+
+	return writeFont(out), nil
+}
+
+// readTables returns the tables of font index of src, a font or a font
+// collection.
+func readTables(src []byte, index int) (map[string][]byte, error) {
+	if len(src) < 12 {
+		return nil, fmt.Errorf("font too short")
+	}
+
+	dir := 0
+
+	if string(src[:4]) == "ttcf" {
+		if index >= int(binary.BigEndian.Uint32(src[8:])) {
+			return nil, fmt.Errorf("no font %d in collection", index)
+		}
+
+		dir = int(binary.BigEndian.Uint32(src[12+4*index:]))
+	}
+
+	n := int(binary.BigEndian.Uint16(src[dir+4:]))
+	tables := map[string][]byte{}
+
+	for i := 0; i < n; i++ {
+		record := src[dir+12+16*i:]
+		offset := binary.BigEndian.Uint32(record[8:])
+		length := binary.BigEndian.Uint32(record[12:])
+
+		if int(offset+length) > len(src) {
+			return nil, fmt.Errorf("table %q out of bounds", record[:4])
+		}
+
+		tables[string(record[:4])] = src[offset : offset+length]
+	}
+
+	return tables, nil
+}
+
+func glyphOffsets(loca []byte, numGlyphs int, long bool) []uint32 {
+	offsets := make([]uint32, numGlyphs+1)
+
+	for i := range offsets {
+		if long {
+			offsets[i] = binary.BigEndian.Uint32(loca[4*i:])
+		} else {
+			offsets[i] = 2 * uint32(binary.BigEndian.Uint16(loca[2*i:]))
+		}
+	}
+
+	return offsets
+}
+
+func hMetric(hmtx []byte, numHMetrics, gid int) (advance, lsb uint16) {
+	if gid < numHMetrics {
+		return binary.BigEndian.Uint16(hmtx[4*gid:]), binary.BigEndian.Uint16(hmtx[4*gid+2:])
+	}
+
+	advance = binary.BigEndian.Uint16(hmtx[4*(numHMetrics-1):])
+	lsb = binary.BigEndian.Uint16(hmtx[4*numHMetrics+2*(gid-numHMetrics):])
+
+	return advance, lsb
+}
+
+// Composite glyph flags
+const (
+	argsAreWords   = 0x0001
+	haveScale      = 0x0008
+	moreComponents = 0x0020
+	haveXYScale    = 0x0040
+	haveTwoByTwo   = 0x0080
+)
+
+// componentOffsets returns the offsets of the glyph index of each component
+// of a composite glyph.
+func componentOffsets(data []byte) []int {
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return nil
+	}
+
+	var offsets []int
+
+	for p := 10; ; {
+		flags := binary.BigEndian.Uint16(data[p:])
+		offsets = append(offsets, p+2)
+		p += 4
+
+		if flags&argsAreWords != 0 {
+			p += 4
+		} else {
+			p += 2
+		}
+
+		switch {
+		case flags&haveScale != 0:
+			p += 2
+		case flags&haveXYScale != 0:
+			p += 4
+		case flags&haveTwoByTwo != 0:
+			p += 8
+		}
+
+		if flags&moreComponents == 0 {
+			return offsets
+		}
+	}
+}
+
+func components(data []byte) []int {
+	var gids []int
+
+	for _, p := range componentOffsets(data) {
+		gids = append(gids, int(binary.BigEndian.Uint16(data[p:])))
+	}
+
+	return gids
+}
+
+func remapComponents(data []byte, newID map[int]int) {
+	for _, p := range componentOffsets(data) {
+		binary.BigEndian.PutUint16(data[p:], uint16(newID[int(binary.BigEndian.Uint16(data[p:]))]))
+	}
+}
+
+// buildCmap returns a cmap with a single Windows full-repertoire format 12
+// subtable.
+func buildCmap(runes []rune, gid func(rune) int) []byte {
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	var groups [][3]uint32
+
+	for _, r := range runes {
+		g := uint32(gid(r))
+
+		if n := len(groups); n > 0 && groups[n-1][1]+1 == uint32(r) && groups[n-1][2]+uint32(r)-groups[n-1][0] == g {
+			groups[n-1][1] = uint32(r)
+			continue
+		}
+
+		groups = append(groups, [3]uint32{uint32(r), uint32(r), g})
+	}
+
+	var b bytes.Buffer
+
+	binary.Write(&b, binary.BigEndian, []uint16{0, 1, 3, 10})
+	binary.Write(&b, binary.BigEndian, uint32(12))
+	binary.Write(&b, binary.BigEndian, []uint16{12, 0})
+	binary.Write(&b, binary.BigEndian, []uint32{uint32(16 + 12*len(groups)), 0, uint32(len(groups))})
+	binary.Write(&b, binary.BigEndian, groups)
+
+	return b.Bytes()
+}
+
+func checksum(data []byte) uint32 {
+	var sum uint32
+
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+
+	return sum
+}
+
+func writeFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	n := len(tags)
+	entrySelector := 0
+
+	for 1<<(entrySelector+1) <= n {
+		entrySelector++
+	}
+
+	searchRange := 16 << entrySelector
+
+	var b bytes.Buffer
+
+	binary.Write(&b, binary.BigEndian, uint32(0x00010000))
+	binary.Write(&b, binary.BigEndian, []uint16{uint16(n), uint16(searchRange), uint16(entrySelector), uint16(16*n - searchRange)})
+
+	offset := 12 + 16*n
+	headOffset := 0
+
+	for _, tag := range tags {
+		data := tables[tag]
+
+		if tag == "head" {
+			headOffset = offset
+		}
+
+		b.WriteString(tag)
+		binary.Write(&b, binary.BigEndian, []uint32{checksum(data), uint32(offset), uint32(len(data))})
+
+		offset += (len(data) + 3) &^ 3
+	}
+
+	for _, tag := range tags {
+		b.Write(tables[tag])
+
+		for b.Len()%4 != 0 {
+			b.WriteByte(0)
+		}
+	}
+
+	font := b.Bytes()
+	binary.BigEndian.PutUint32(font[headOffset+8:], 0xB1B0AFBA-checksum(font))
+
+	return font
+}
+
+// point is an outline point in font units, y up.
+type point struct {
+	x, y float64
+	on   bool
+}
+
+// quadraticGlyph returns glyph gid of f as TrueType simple glyph data, its
+// cubic curves approximated by quadratic ones.
+func quadraticGlyph(f *sfnt.Font, gid int) ([]byte, error) {
+	upem := fixed.Int26_6(f.UnitsPerEm()) << 6
+	segments, err := f.LoadGlyph(nil, sfnt.GlyphIndex(gid), upem, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var contours [][]point
+	var last point
+
+	add := func(p fixed.Point26_6, on bool) point {
+		pt := point{float64(p.X) / 64, -float64(p.Y) / 64, on}
+		contours[len(contours)-1] = append(contours[len(contours)-1], pt)
+
+		return pt
+	}
+
+	for _, s := range segments {
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo:
+			contours = append(contours, nil)
+			last = add(s.Args[0], true)
+		case sfnt.SegmentOpLineTo:
+			last = add(s.Args[0], true)
+		case sfnt.SegmentOpQuadTo:
+			add(s.Args[0], false)
+			last = add(s.Args[1], true)
+		case sfnt.SegmentOpCubeTo:
+			c1 := point{float64(s.Args[0].X) / 64, -float64(s.Args[0].Y) / 64, false}
+			c2 := point{float64(s.Args[1].X) / 64, -float64(s.Args[1].Y) / 64, false}
+			end := point{float64(s.Args[2].X) / 64, -float64(s.Args[2].Y) / 64, true}
+
+			for _, q := range cubicToQuadratics(last, c1, c2, end, 0.5) {
+				contours[len(contours)-1] = append(contours[len(contours)-1], q...)
+			}
+
+			last = end
+		}
+	}
+
+	return encodeSimpleGlyph(contours), nil
+}
+
+// cubicToQuadratics splits the cubic curve p0 c1 c2 p3 into as few pieces as
+// keep each within tolerance of a quadratic curve, returning the control and
+// end point of each piece.
+func cubicToQuadratics(p0, c1, c2, p3 point, tolerance float64) [][]point {
+	at := func(t float64) (x, y float64) {
+		u := 1 - t
+
+		return u*u*u*p0.x + 3*u*u*t*c1.x + 3*u*t*t*c2.x + t*t*t*p3.x,
+			u*u*u*p0.y + 3*u*u*t*c1.y + 3*u*t*t*c2.y + t*t*t*p3.y
+	}
+	tangent := func(t float64) (x, y float64) {
+		u := 1 - t
+
+		return 3*u*u*(c1.x-p0.x) + 6*u*t*(c2.x-c1.x) + 3*t*t*(p3.x-c2.x),
+			3*u*u*(c1.y-p0.y) + 6*u*t*(c2.y-c1.y) + 3*t*t*(p3.y-c2.y)
+	}
+
+	var pieces [][]point
+
+	for n := 1; ; n++ {
+		pieces = pieces[:0]
+		fits := true
+
+		for i := 0; i < n; i++ {
+			t0, t1 := float64(i)/float64(n), float64(i+1)/float64(n)
+			ax, ay := at(t0)
+			dx, dy := at(t1)
+			tx0, ty0 := tangent(t0)
+			tx1, ty1 := tangent(t1)
+
+			// The quadratic control point closest to the two cubic ones
+			bx, by := ax+tx0*(t1-t0)/3, ay+ty0*(t1-t0)/3
+			cx, cy := dx-tx1*(t1-t0)/3, dy-ty1*(t1-t0)/3
+			qx, qy := (3*(bx+cx)-(ax+dx))/4, (3*(by+cy)-(ay+dy))/4
+
+			for _, s := range []float64{0.25, 0.5, 0.75} {
+				x, y := at(t0 + s*(t1-t0))
+				u := 1 - s
+				ex := u*u*ax + 2*u*s*qx + s*s*dx - x
+				ey := u*u*ay + 2*u*s*qy + s*s*dy - y
+
+				if ex*ex+ey*ey > tolerance*tolerance {
+					fits = false
+				}
+			}
+
+			pieces = append(pieces, []point{{qx, qy, false}, {dx, dy, true}})
+		}
+
+		if fits || n == 16 {
+			return pieces
+		}
+	}
+}
+
+// encodeSimpleGlyph returns the glyf data of the given closed contours.
+func encodeSimpleGlyph(contours [][]point) []byte {
+	var xs, ys []int16
+	var flags []byte
+	var ends []uint16
+
+	for _, c := range contours {
+		// Contours close themselves; drop an explicit return to the start
+		if n := len(c); n > 1 && c[n-1] == c[0] {
+			c = c[:n-1]
+		}
+
+		if len(c) == 0 {
+			continue
+		}
+
+		for _, p := range c {
+			xs = append(xs, int16(math.Round(p.x)))
+			ys = append(ys, int16(math.Round(p.y)))
+
+			if p.on {
+				flags = append(flags, 1)
+			} else {
+				flags = append(flags, 0)
+			}
+		}
+
+		ends = append(ends, uint16(len(xs)-1))
+	}
+
+	if len(ends) == 0 {
+		return nil
+	}
+
+	xMin, yMin, xMax, yMax := xs[0], ys[0], xs[0], ys[0]
+
+	for i := range xs {
+		xMin, xMax = min(xMin, xs[i]), max(xMax, xs[i])
+		yMin, yMax = min(yMin, ys[i]), max(yMax, ys[i])
+	}
+
+	var b bytes.Buffer
+
+	binary.Write(&b, binary.BigEndian, []int16{int16(len(ends)), xMin, yMin, xMax, yMax})
+	binary.Write(&b, binary.BigEndian, ends)
+	binary.Write(&b, binary.BigEndian, uint16(0)) // no instructions
+	b.Write(flags)
+
+	for _, coords := range [][]int16{xs, ys} {
+		var prev int16
+
+		for _, v := range coords {
+			binary.Write(&b, binary.BigEndian, v-prev)
+			prev = v
+		}
+	}
+
+	return b.Bytes()
+}
+
+// trueTypeMaxp returns a version 1.0 maxp table for the converted glyphs,
+// which CFF fonts lack.
+func trueTypeMaxp(glyf, loca []byte) []byte {
+	var points, contours uint16
+
+	for i := 0; i+8 <= len(loca); i += 4 {
+		data := glyf[binary.BigEndian.Uint32(loca[i:]):binary.BigEndian.Uint32(loca[i+4:])]
+
+		if len(data) < 10 {
+			continue
+		}
+
+		n := binary.BigEndian.Uint16(data)
+		contours = max(contours, n)
+		points = max(points, binary.BigEndian.Uint16(data[10+2*(n-1):])+1)
+	}
+
+	maxp := make([]byte, 32)
+	binary.BigEndian.PutUint32(maxp[0:], 0x00010000)
+	binary.BigEndian.PutUint16(maxp[6:], points)
+	binary.BigEndian.PutUint16(maxp[8:], contours)
+	binary.BigEndian.PutUint16(maxp[14:], 2) // maxZones
+
+	return maxp
+}
@@ -0,0 +1,135 @@
+package render
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterFont(t *testing.T) {
+	data, err := fontFS.ReadFile("fonts/inter-extrabold.ttf")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RegisterFont("brand-test", data); err != nil {
+		t.Fatalf("RegisterFont failed: %v", err)
+	}
+
+	typer, err := NewTypingText(TypingTextOptions{Font: "brand-test", Text: "HI", Width: 200, Height: 80})
+
+	if err != nil {
+		t.Fatalf("NewTypingText with a registered font failed: %v", err)
+	}
+
+	if typer.font != "brand-test" {
+		t.Errorf("font = %q, want brand-test", typer.font)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Brand", data},
+		{"../brand", data},
+		{"impact", data},
+		{"broken", []byte("not a font")},
+		{"huge", make([]byte, maxFontBytes+1)},
+	}
+
+	for _, tt := range tests {
+		err := RegisterFont(tt.name, tt.data)
+
+		var optErr *OptionError
+
+		if !errors.As(err, &optErr) || optErr.Field != "font" {
+			t.Errorf("RegisterFont(%q) error = %v, want OptionError for font", tt.name, err)
+		}
+	}
+}
+
+func TestFallbackFaceCoversTranslations(t *testing.T) {
+	face, err := loadFont("impact", 16, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Latin stays with Impact itself
+	if chain := face.(*fallbackFace); chain.face('D') != chain.faceAt(0) {
+		t.Errorf("'D' is not drawn with the primary font")
+	}
+
+	for name := range ALLOWED_FONTS {
+		face, err := loadFont(name, 16, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		chain := face.(*fallbackFace)
+
+		for lang, labels := range translations {
+			for key, label := range labels {
+				// Labels are drawn shaped and, in most layouts, upper case
+				for _, r := range label + strings.ToUpper(label) + shapeText(label) {
+					if r != ' ' && !hasGlyph(chain, r) {
+						t.Errorf("%s: %s %s: no glyph for %q", name, lang, key, r)
+					}
+				}
+			}
+		}
+	}
+}
+
+// hasGlyph reports whether any font of the chain has a glyph for r.
+func hasGlyph(chain *fallbackFace, r rune) bool {
+	for _, f := range chain.fonts {
+		if f.Index(r) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestSetFontFallbacks(t *testing.T) {
+	if err := SetFontFallbacks("impact", "nope"); err == nil {
+		t.Error("SetFontFallbacks accepted an unknown font")
+	}
+
+	if err := SetFontFallbacks("playwrite-regular", "inter-extrabold"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer SetFontFallbacks("playwrite-regular")
+
+	fontCacheMu.Lock()
+	chain := fallbackChain("playwrite-regular")
+	fontCacheMu.Unlock()
+
+	if len(chain) < 3 || chain[1] != "inter-extrabold" || chain[2] != fallbackFonts[0] {
+		t.Errorf("chain = %v, want playwrite-regular, inter-extrabold, then the embedded fallbacks", chain)
+	}
+}
+
+func TestFaceCacheIsBounded(t *testing.T) {
+	for size := 10.0; size < 10+2*maxCachedFaces; size++ {
+		if _, err := loadFont("impact", size, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	if n := faceCache.order.Len(); n != maxCachedFaces || len(faceCache.items) != maxCachedFaces {
+		t.Errorf("cache holds %d faces, want %d", n, maxCachedFaces)
+	}
+
+	// The most recently loaded sizes are the ones kept
+	if _, ok := faceCache.get(faceKey{"impact", 10 + 2*maxCachedFaces - 1, 0}); !ok {
+		t.Error("latest face was evicted")
+	}
+}
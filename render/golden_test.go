@@ -0,0 +1,344 @@
+package render
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate golden files in testdata/golden")
+
+// golden is the checked-in summary of a rendered GIF.
+type golden struct {
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	Frames int      `json:"frames"`
+	Delays []int    `json:"delays"`
+	Hashes []string `json:"hashes"`
+}
+
+var goldenTests = []struct {
+	name      string
+	generator string
+	params    map[string]string
+}{
+	{"countdown-basic", "countdown", map[string]string{
+		"kind":   "basic",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "3",
+	}},
+	{"countdown-rounded", "countdown", map[string]string{
+		"kind":       "rounded",
+		"date":       "2026-01-01T00:00:00.000Z",
+		"now":        "2025-12-01T10:20:30.000Z",
+		"frames":     "3",
+		"background": "333333",
+		"color":      "00ff00",
+	}},
+	{"countdown-rounded-ticks", "countdown", map[string]string{
+		"kind":   "rounded-ticks",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "3",
+		"lang":   "de",
+	}},
+	{"countdown-rounded-dots", "countdown", map[string]string{
+		"kind":   "rounded-dots",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "3",
+	}},
+	{"countdown-flip", "countdown", map[string]string{
+		"kind":   "flip",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:59.000Z",
+		"frames": "2",
+	}},
+	{"countdown-digital", "countdown", map[string]string{
+		"kind":   "digital",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:59.000Z",
+		"frames": "2",
+	}},
+	{"countdown-digital-expiring", "countdown", map[string]string{
+		"kind":   "digital",
+		"date":   "2025-12-01T10:20:32.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "5",
+	}},
+	{"countdown-bars", "countdown", map[string]string{
+		"kind":   "bars",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"start":  "2025-01-01",
+		"now":    "2025-12-01T10:20:59.000Z",
+		"frames": "2",
+	}},
+	{"countdown-he", "countdown", map[string]string{
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "1",
+		"lang":   "he",
+	}},
+	{"countdown-ar", "countdown", map[string]string{
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "1",
+		"lang":   "ar",
+	}},
+	{"countdown-small", "countdown", map[string]string{
+		"kind":   "rounded-ticks",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "2",
+		"width":  "360",
+		"height": "140",
+	}},
+	{"countdown-weeks", "countdown", map[string]string{
+		"kind":   "rounded",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "2",
+		"units":  "weeks,days,hours",
+		"lang":   "fr",
+	}},
+	{"countdown-hours-basic", "countdown", map[string]string{
+		"kind":   "basic",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "2",
+		"units":  "hours,minutes,seconds",
+	}},
+	{"countdown-hours-rounded", "countdown", map[string]string{
+		"kind":   "rounded",
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "2",
+		"units":  "hours,minutes,seconds",
+	}},
+	{"countdown-timezone", "countdown", map[string]string{
+		"kind":     "basic",
+		"date":     "2026-01-01T00:00:00",
+		"now":      "2025-12-01T10:20:30.000Z",
+		"frames":   "2",
+		"timezone": "America/Sao_Paulo",
+	}},
+	{"countdown-up", "countdown", map[string]string{
+		"kind":      "rounded",
+		"direction": "up",
+		"date":      "2025-01-01T00:00:00.000Z",
+		"now":       "2025-12-01T10:20:30.000Z",
+		"frames":    "2",
+	}},
+	{"countdown-up-ticks", "countdown", map[string]string{
+		"kind":      "rounded-ticks",
+		"direction": "up",
+		"date":      "2025-01-01T00:00:00.000Z",
+		"now":       "2025-12-01T10:20:30.000Z",
+		"frames":    "2",
+	}},
+	{"countdown-expired", "countdown", map[string]string{
+		"date": "2025-01-01T00:00:00.000Z",
+		"now":  "2025-12-01T10:20:30.000Z",
+	}},
+	{"countdown-expired-text", "countdown", map[string]string{
+		"date":    "2025-01-01T00:00:00.000Z",
+		"now":     "2025-12-01T10:20:30.000Z",
+		"lang":    "pt",
+		"expired": "text",
+	}},
+	{"countdown-expired-custom", "countdown", map[string]string{
+		"date":              "2025-01-01T00:00:00.000Z",
+		"now":               "2025-12-01T10:20:30.000Z",
+		"expiredText":       "SALE ENDED",
+		"expiredBackground": "#ffffff",
+		"expiredColor":      "#cc0000",
+	}},
+	{"countdown-expiring", "countdown", map[string]string{
+		"date":              "2025-12-01T10:20:32.000Z",
+		"now":               "2025-12-01T10:20:30.000Z",
+		"frames":            "4",
+		"expiredText":       "SALE ENDED",
+		"expiredBackground": "#ffffff",
+		"expiredColor":      "#cc0000",
+	}},
+	{"countdown-expired-image", "countdown", map[string]string{
+		"date":         "2025-01-01T00:00:00.000Z",
+		"now":          "2025-12-01T10:20:30.000Z",
+		"expiredImage": testImageBase64(),
+	}},
+	{"led-banner", "led-banner", map[string]string{
+		"text":   "BLACK FRIDAY",
+		"frames": "5",
+	}},
+	{"flashing-letters", "flashing-letters", map[string]string{
+		"text":             "SALE",
+		"frames":           "3",
+		"flashProbability": "0",
+	}},
+	{"flashing-letters-seeded", "flashing-letters", map[string]string{
+		"text":             "SALE",
+		"frames":           "4",
+		"flashProbability": "0.5",
+		"seed":             "7",
+	}},
+	{"flashing-text", "flashing-text", map[string]string{
+		"text":   "SALE",
+		"frames": "4",
+		"words":  "4",
+	}},
+	{"flashing-text-seeded", "flashing-text", map[string]string{
+		"text":   "SALE",
+		"frames": "4",
+		"words":  "4",
+		"seed":   "42",
+	}},
+	{"color-varying", "color-varying", map[string]string{
+		"text":        "Big summer sale",
+		"frames":      "4",
+		"colorScheme": "triadic",
+	}},
+	{"typing", "typing", map[string]string{
+		"text": "HELLO",
+	}},
+	{"typing-font", "typing", map[string]string{
+		"text": "HELLO",
+		"font": "inter-extrabold",
+	}},
+	{"typing-per-word", "typing", map[string]string{
+		"text":    "Olá mundo 👋🏽",
+		"perWord": "true",
+	}},
+	{"countdown-font", "countdown", map[string]string{
+		"date":   "2026-01-01T00:00:00.000Z",
+		"now":    "2025-12-01T10:20:30.000Z",
+		"frames": "2",
+		"font":   "playwrite-regular",
+	}},
+}
+
+func TestGolden(t *testing.T) {
+	for _, tt := range goldenTests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := StringParams(func(key string) string {
+				return tt.params[key]
+			})
+
+			b, err := Build(tt.generator, params)
+
+			if err != nil {
+				t.Fatalf("Build(%q) failed: %v", tt.generator, err)
+			}
+
+			got := summarize(t, b)
+			path := filepath.Join("testdata", "golden", tt.name+".json")
+
+			if *update {
+				data, err := json.MarshalIndent(got, "", "\t")
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			data, err := os.ReadFile(path)
+
+			if err != nil {
+				t.Fatalf("missing golden file (run with -update): %v", err)
+			}
+
+			var want golden
+
+			if err := json.Unmarshal(data, &want); err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Width != want.Width || got.Height != want.Height {
+				t.Errorf("size = %dx%d, want %dx%d", got.Width, got.Height, want.Width, want.Height)
+			}
+
+			if got.Frames != want.Frames {
+				t.Fatalf("frames = %d, want %d", got.Frames, want.Frames)
+			}
+
+			if !reflect.DeepEqual(got.Delays, want.Delays) {
+				t.Errorf("delays = %v, want %v", got.Delays, want.Delays)
+			}
+
+			for i := range want.Hashes {
+				if got.Hashes[i] != want.Hashes[i] {
+					t.Errorf("frame %d hash = %s, want %s", i, got.Hashes[i], want.Hashes[i])
+				}
+			}
+		})
+	}
+}
+
+// testImageBase64 returns a small two-tone PNG as a data URL.
+func testImageBase64() string {
+	img := image.NewRGBA(image.Rect(0, 0, 80, 40))
+
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 80; x++ {
+			if x < 40 {
+				img.Set(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				img.Set(x, y, color.RGBA{0, 0, 255, 255})
+			}
+		}
+	}
+
+	b := new(bytes.Buffer)
+	png.Encode(b, img)
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(b.Bytes())
+}
+
+// summarize decodes b and hashes the palette and pixels of every frame.
+func summarize(t *testing.T, b []byte) golden {
+	t.Helper()
+
+	g, err := gif.DecodeAll(bytes.NewReader(b))
+
+	if err != nil {
+		t.Fatalf("failed to decode gif: %v", err)
+	}
+
+	s := golden{
+		Width:  g.Config.Width,
+		Height: g.Config.Height,
+		Frames: len(g.Image),
+		Delays: g.Delay,
+	}
+
+	for _, frame := range g.Image {
+		h := sha256.New()
+
+		for _, c := range frame.Palette {
+			r, g, b, a := c.RGBA()
+			h.Write([]byte{byte(r >> 8), byte(g >> 8), byte(b >> 8), byte(a >> 8)})
+		}
+
+		h.Write(frame.Pix)
+		s.Hashes = append(s.Hashes, hex.EncodeToString(h.Sum(nil)))
+	}
+
+	return s
+}
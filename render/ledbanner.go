@@ -0,0 +1,168 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+type LedBanner struct {
+	bg        color.Color
+	color     color.Color
+	delay     float64
+	font      string
+	forward   bool
+	frames    int
+	height    int
+	spaceSize int
+	text      string
+	width     int
+
+	fontFace       font.Face
+	textWidth      float64
+	continuousText string
+}
+
+type LedBannerOptions struct {
+	Background string
+	Color      string
+	Delay      float64
+	Font       string
+	Forward    bool
+	Frames     int
+	Height     int
+	SpaceSize  int
+	Text       string
+	Width      int
+}
+
+func NewLedBanner(opts LedBannerOptions) (*LedBanner, error) {
+	if opts.Frames < 1 {
+		opts.Frames = 1
+	} else if opts.Frames > 30 {
+		opts.Frames = 30
+	}
+
+	opts.Width = clampCanvasSide(opts.Width, 800)
+	opts.Height = clampCanvasSide(opts.Height, 50)
+
+	if opts.SpaceSize < 0 {
+		opts.SpaceSize = 0
+	} else if opts.SpaceSize > 100 {
+		opts.SpaceSize = 100
+	}
+
+	fontName, err := resolveFont(opts.Font)
+
+	if err != nil {
+		return nil, err
+	}
+
+	l := &LedBanner{
+		bg:        parseHexString(opts.Background),
+		color:     parseHexString(opts.Color),
+		delay:     opts.Delay,
+		font:      fontName,
+		forward:   opts.Forward,
+		frames:    opts.Frames,
+		height:    opts.Height,
+		spaceSize: opts.SpaceSize,
+		text:      shapeText(opts.Text),
+		width:     opts.Width,
+	}
+
+	// Create font face
+	fontFace, err := loadFont(l.font, float64(l.height)*0.8, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	space := strings.Repeat(" ", l.spaceSize)
+
+	// Calculate text width and prepare continuous text
+	dc := gg.NewContext(l.width, l.height)
+	dc.SetFontFace(fontFace)
+	textWidth, _ := dc.MeasureString(l.text + space)
+
+	// Calculate how many copies of the text we need to fill the screen plus one extra
+	copies := int(math.Ceil(float64(l.width)/textWidth)) + 2
+
+	l.fontFace = fontFace
+	l.textWidth = textWidth
+	l.continuousText = strings.Repeat(l.text+space, copies)
+
+	return l, nil
+}
+
+func init() {
+	Register("led-banner", func(p Params) (Renderer, error) {
+		banner, err := NewLedBanner(LedBannerOptions{
+			Background: p.String("background", p.String("bg", "#000000")),
+			Color:      p.String("color", "#ffffff"),
+			Delay:      p.Float("delay", 50),
+			Font:       p.String("font", ""),
+			Forward:    p.Bool("forward", true),
+			Frames:     p.Int("frames", 10),
+			Height:     p.Int("height", 50),
+			SpaceSize:  p.Int("spaceSize", 4),
+			Text:       p.String("text", "Hello World!"),
+			Width:      p.Int("width", 800),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return banner, nil
+	})
+}
+
+func (l *LedBanner) Create() ([]byte, error) {
+	return Encode(l)
+}
+
+func (l *LedBanner) Frames() int {
+	return l.frames
+}
+
+func (l *LedBanner) Delay(i int) int {
+	return int(l.delay / 10) // time in GIF is by 100ths of a second
+}
+
+func (l *LedBanner) Palette(i int) color.Palette {
+	return blendPalette(l.color, l.bg)
+}
+
+func (l *LedBanner) Frame(i int) (image.Image, error) {
+	dc := gg.NewContext(l.width, l.height)
+
+	// Set background
+	dc.SetColor(l.bg)
+	dc.Clear()
+
+	// Calculate text position for scrolling effect
+	offset := float64(i) * l.textWidth / float64(l.frames)
+	x := -offset
+	y := float64(l.height) / 2
+
+	if l.forward {
+		x = -l.textWidth + offset
+	}
+
+	// Draw LED effect
+	l.drawLedText(dc, l.fontFace, x, y, l.continuousText)
+
+	return dc.Image(), nil
+}
+
+func (l *LedBanner) drawLedText(dc *gg.Context, fontFace font.Face, x, y float64, text string) {
+	dc.SetFontFace(fontFace)
+
+	// Draw main text
+	dc.SetColor(l.color)
+	dc.DrawStringAnchored(text, x, y, 0, 0.5)
+}
@@ -0,0 +1,169 @@
+// Package render builds the animated GIFs (countdowns, banners and text
+// effects) independently of the WebAssembly entry point.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OptionError reports an invalid option passed to a generator. Field holds
+// the option name as accepted by the JS and HTTP APIs (e.g. "date").
+type OptionError struct {
+	Field string
+	Err   error
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Field, e.Err)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+func parseHexString(s string) color.Color {
+	var r, g, b uint8
+
+	if !strings.HasPrefix(s, "#") {
+		s = "#" + s
+	}
+
+	if len(s) == 7 {
+		fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b)
+	} else if len(s) == 4 {
+		fmt.Sscanf(s, "#%1x%1x%1x", &r, &g, &b)
+		r *= 17
+		g *= 17
+		b *= 17
+	} else {
+		r, g, b = 255, 255, 255
+	}
+
+	return color.RGBA{r, g, b, 255}
+}
+
+// wallClockLayouts are date layouts without a UTC offset; they are read as
+// wall-clock time in the caller's location.
+var wallClockLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseDateString accepts RFC 3339 (with or without fractional seconds and
+// offset), "YYYY-MM-DD HH:MM[:SS]", "YYYY-MM-DD" and Unix timestamps in
+// seconds or milliseconds. Values without an offset are wall-clock time in
+// loc; "Z" and numeric offsets are absolute.
+func parseDateString(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return time.Time{}, errors.New("empty date")
+	}
+
+	if isDigits(s) {
+		n, err := strconv.ParseInt(s, 10, 64)
+
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid Unix timestamp %q", s)
+		}
+
+		// Seconds reach 12 digits only after the year 5000; anything longer
+		// is taken as milliseconds, as produced by Date.now().
+		if len(s) >= 12 {
+			return time.UnixMilli(n).In(loc), nil
+		}
+
+		return time.Unix(n, 0).In(loc), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range wallClockLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q, want RFC 3339, YYYY-MM-DD, YYYY-MM-DD HH:MM or a Unix timestamp", s)
+}
+
+// ParseDate parses s like the "date" and "now" options, reading values
+// without an offset as UTC.
+func ParseDate(s string) (time.Time, error) {
+	return parseDateString(s, time.UTC)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// maxCanvasSide bounds the width and height of every generator, since each
+// frame allocates width*height*4 bytes.
+const maxCanvasSide = 2000
+
+// clampCanvasSide limits side to maxCanvasSide, using fallback when it is
+// not positive.
+func clampCanvasSide(side, fallback int) int {
+	if side < 1 {
+		return fallback
+	}
+
+	return min(side, maxCanvasSide)
+}
+
+// blendColor mixes fg and bg, going from fg at t=0 to bg at t=1.
+func blendColor(fg, bg color.Color, t float64) color.Color {
+	r1, g1, b1, _ := fg.RGBA()
+	r2, g2, b2, _ := bg.RGBA()
+
+	r := uint8((1-t)*float64(r1>>8) + t*float64(r2>>8))
+	g := uint8((1-t)*float64(g1>>8) + t*float64(g2>>8))
+	b := uint8((1-t)*float64(b1>>8) + t*float64(b2>>8))
+
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
+
+// blendPalette returns bg followed by 255 shades fading from fg to bg.
+func blendPalette(fg, bg color.Color) color.Palette {
+	var palette color.Palette
+
+	palette = append(palette, bg)
+
+	for i := 1; i < 256; i++ {
+		t := float64(i) / 255.0
+		palette = append(palette, blendColor(fg, bg, t))
+	}
+
+	return palette
+}
+
+// seedFromOptions derives a random seed from the values that shape a
+// generator's output, so identical options always produce identical output.
+// Generators pass an explicit list rather than their options struct, so that
+// adding an option does not change the seed of existing URLs.
+func seedFromOptions(values ...interface{}) int64 {
+	h := fnv.New64a()
+
+	for _, v := range values {
+		fmt.Fprintf(h, "%v\x00", v)
+	}
+
+	return int64(h.Sum64())
+}
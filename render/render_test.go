@@ -0,0 +1,125 @@
+package render
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDateString(t *testing.T) {
+	saoPaulo, err := parseTimezone("America/Sao_Paulo")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-01-01T00:00:00.000Z", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-01-01T00:00:00Z", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-01-01T00:00:00+00:00", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-01-01T00:00:00.5Z", time.Date(2026, 1, 1, 0, 0, 0, 5e8, time.UTC)},
+		{"2026-01-01T00:00:00+05:30", time.Date(2025, 12, 31, 18, 30, 0, 0, time.UTC)},
+		{"2026-01-01T00:00:00.5-01:00", time.Date(2026, 1, 1, 1, 0, 0, 5e8, time.UTC)},
+		{"2026-01-01T12:30:15", time.Date(2026, 1, 1, 15, 30, 15, 0, time.UTC)},
+		{"2026-01-01T12:30", time.Date(2026, 1, 1, 15, 30, 0, 0, time.UTC)},
+		{"2026-01-01 12:30", time.Date(2026, 1, 1, 15, 30, 0, 0, time.UTC)},
+		{"2026-01-01", time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)},
+		{"1767225600", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"1767225600250", time.Date(2026, 1, 1, 0, 0, 0, 25e7, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDateString(tt.in, saoPaulo)
+
+		if err != nil {
+			t.Errorf("parseDateString(%q) failed: %v", tt.in, err)
+			continue
+		}
+
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDateString(%q) = %v, want %v", tt.in, got.UTC(), tt.want)
+		}
+	}
+}
+
+func TestParseDateStringRejectsInvalid(t *testing.T) {
+	for _, in := range []string{"", "tomorrow", "2026-13-01", "01/02/2026", "2026-01-01T25:00", "-1767225600"} {
+		if _, err := parseDateString(in, time.UTC); err == nil {
+			t.Errorf("parseDateString(%q) succeeded, want error", in)
+		}
+	}
+
+	_, err := NewCountdown(CountdownOptions{TargetDate: "next friday"})
+
+	var optErr *OptionError
+
+	if !errors.As(err, &optErr) || optErr.Field != "date" {
+		t.Errorf("NewCountdown error = %v, want OptionError for date", err)
+	}
+}
+
+func TestCountdownRejectsLateStart(t *testing.T) {
+	_, err := NewCountdown(CountdownOptions{
+		Kind:       "bars",
+		StartDate:  "2026-02-01",
+		TargetDate: "2026-01-01",
+	})
+
+	var optErr *OptionError
+
+	if !errors.As(err, &optErr) || optErr.Field != "start" {
+		t.Errorf("NewCountdown error = %v, want OptionError for start", err)
+	}
+}
+
+func TestBuildRejectsUnknownFont(t *testing.T) {
+	for _, name := range Names() {
+		_, err := Build(name, StringParams(func(key string) string {
+			if key == "font" {
+				return "comic-sans"
+			}
+
+			return ""
+		}))
+
+		var optErr *OptionError
+
+		if !errors.As(err, &optErr) || optErr.Field != "font" {
+			t.Errorf("%s: error = %v, want OptionError for font", name, err)
+		}
+	}
+}
+
+func TestFactoriesClampCanvasSize(t *testing.T) {
+	for _, name := range Names() {
+		factory, _ := Lookup(name)
+
+		r, err := factory(StringParams(func(key string) string {
+			switch key {
+			case "width", "height":
+				return "50000"
+			case "frames":
+				return "1"
+			}
+
+			return ""
+		}))
+
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		frame, err := r.Frame(0)
+
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if size := frame.Bounds().Size(); size.X > maxCanvasSide || size.Y > maxCanvasSide {
+			t.Errorf("%s: frame is %v, want at most %dx%d", name, size, maxCanvasSide, maxCanvasSide)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Renderer is implemented by every animation. Frames are drawn independently
+// and converted to their palette by Encode.
+type Renderer interface {
+	// Frames returns the number of frames in the animation.
+	Frames() int
+	// Delay returns the delay after frame i, in 100ths of a second.
+	Delay(i int) int
+	// Palette returns the palette frame i is quantized to.
+	Palette(i int) color.Palette
+	// Frame draws frame i.
+	Frame(i int) (image.Image, error)
+}
+
+// Params gives a Factory access to options by name, regardless of whether
+// they come from JS, a URL query or the command line.
+type Params interface {
+	String(key string, fallback string) string
+	Int(key string, fallback int) int
+	Float(key string, fallback float64) float64
+	Bool(key string, fallback bool) bool
+}
+
+// Factory builds a Renderer from Params, applying its own defaults.
+type Factory func(p Params) (Renderer, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a Factory available under name. It panics if name is
+// already registered.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic("render: Register called twice for " + name)
+	}
+
+	registry[name] = factory
+}
+
+// Lookup returns the Factory registered under name.
+func Lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, ok := registry[name]
+
+	return factory, ok
+}
+
+// Names returns the sorted names of all registered factories.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var names []string
+
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Build creates the renderer registered under name and encodes it as a GIF.
+func Build(name string, p Params) ([]byte, error) {
+	factory, ok := Lookup(name)
+
+	if !ok {
+		return nil, &OptionError{Field: "name", Err: fmt.Errorf("unknown generator %q", name)}
+	}
+
+	r, err := factory(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Encode(r)
+}
+
+// Concurrency bounds how many frames Encode renders at once. Values below 1
+// use runtime.GOMAXPROCS(0).
+var Concurrency = 0
+
+// Encode draws every frame of r and encodes the animation as a GIF. Frames
+// are rendered by a pool of up to Concurrency goroutines, so Frame, Delay
+// and Palette must be safe for concurrent use.
+func Encode(r Renderer) ([]byte, error) {
+	n := r.Frames()
+	images := make([]*image.Paletted, n)
+	delays := make([]int, n)
+	errs := make([]error, n)
+
+	workers := Concurrency
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				images[i], delays[i], errs[i] = encodeFrame(r, i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b := new(bytes.Buffer)
+	err := gif.EncodeAll(b, &gif.GIF{
+		Image: images,
+		Delay: delays,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode gif: %v", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// encodeFrame draws frame i of r and quantizes it to the frame's palette. A
+// panic is returned as an error: it would otherwise escape the worker
+// goroutine and crash the process (or the wasm instance).
+func encodeFrame(r Renderer, i int) (img *image.Paletted, delay int, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("frame %d: %v", i, v)
+		}
+	}()
+
+	frame, err := r.Frame(i)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bounds := frame.Bounds()
+	palettedImage := image.NewPaletted(bounds, r.Palette(i))
+	draw.Draw(palettedImage, palettedImage.Rect, frame, bounds.Min, draw.Src)
+
+	return palettedImage, r.Delay(i), nil
+}
+
+// StringParams implements Params on top of a string lookup such as
+// url.Values.Get. Empty or unparsable values yield the fallback.
+type StringParams func(key string) string
+
+func (p StringParams) String(key string, fallback string) string {
+	if value := p(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func (p StringParams) Int(key string, fallback int) int {
+	value, err := strconv.Atoi(p(key))
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func (p StringParams) Float(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(p(key), 64)
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func (p StringParams) Bool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(p(key))
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
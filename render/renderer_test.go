@@ -0,0 +1,36 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// panickingRenderer draws a blank frame except for frame 1, which panics.
+type panickingRenderer struct{}
+
+func (panickingRenderer) Frames() int { return 3 }
+
+func (panickingRenderer) Delay(i int) int { return 10 }
+
+func (panickingRenderer) Palette(i int) color.Palette {
+	return color.Palette{color.Black, color.White}
+}
+
+func (panickingRenderer) Frame(i int) (image.Image, error) {
+	if i == 1 {
+		var values []int
+		_ = values[i]
+	}
+
+	return image.NewGray(image.Rect(0, 0, 4, 4)), nil
+}
+
+func TestEncodeReturnsFramePanics(t *testing.T) {
+	_, err := Encode(panickingRenderer{})
+
+	if err == nil || !strings.HasPrefix(err.Error(), "frame 1: ") {
+		t.Errorf("Encode error = %v, want the panic of frame 1", err)
+	}
+}
@@ -0,0 +1,374 @@
+package render
+
+import (
+	"strings"
+	"unicode"
+)
+
+// gg draws strings rune by rune, left to right, so right-to-left and
+// Arabic text is shaped here first: letters are replaced by their
+// contextual presentation forms and the string is reordered into visual
+// order. Devanagari only gets its pre-base vowel sign moved; conjuncts keep
+// a visible virama.
+
+// arabicForms holds the isolated, final, initial and medial presentation
+// forms of each Arabic letter. Letters that only join to the preceding one
+// have no initial or medial form.
+var arabicForms = map[rune][4]rune{
+	0x0621: {0xFE80, 0, 0, 0},
+	0x0622: {0xFE81, 0xFE82, 0, 0},
+	0x0623: {0xFE83, 0xFE84, 0, 0},
+	0x0624: {0xFE85, 0xFE86, 0, 0},
+	0x0625: {0xFE87, 0xFE88, 0, 0},
+	0x0626: {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	0x0627: {0xFE8D, 0xFE8E, 0, 0},
+	0x0628: {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	0x0629: {0xFE93, 0xFE94, 0, 0},
+	0x062A: {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	0x062B: {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	0x062C: {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	0x062D: {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	0x062E: {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	0x062F: {0xFEA9, 0xFEAA, 0, 0},
+	0x0630: {0xFEAB, 0xFEAC, 0, 0},
+	0x0631: {0xFEAD, 0xFEAE, 0, 0},
+	0x0632: {0xFEAF, 0xFEB0, 0, 0},
+	0x0633: {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	0x0634: {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	0x0635: {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	0x0636: {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	0x0637: {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	0x0638: {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	0x0639: {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	0x063A: {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	0x0641: {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	0x0642: {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	0x0643: {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	0x0644: {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	0x0645: {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	0x0646: {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	0x0647: {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	0x0648: {0xFEED, 0xFEEE, 0, 0},
+	0x0649: {0xFEEF, 0xFEF0, 0, 0},
+	0x064A: {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4},
+	0x067E: {0xFB56, 0xFB57, 0xFB58, 0xFB59}, // peh
+	0x0686: {0xFB7A, 0xFB7B, 0xFB7C, 0xFB7D}, // tcheh
+	0x0698: {0xFB8A, 0xFB8B, 0, 0},           // jeh
+	0x06A9: {0xFB8E, 0xFB8F, 0xFB90, 0xFB91}, // keheh
+	0x06AF: {0xFB92, 0xFB93, 0xFB94, 0xFB95}, // gaf
+	0x06CC: {0xFBFC, 0xFBFD, 0xFBFE, 0xFBFF}, // farsi yeh
+}
+
+// lamAlef maps the alef following a lam to the isolated and final forms of
+// their mandatory ligature.
+var lamAlef = map[rune][2]rune{
+	0x0622: {0xFEF5, 0xFEF6},
+	0x0623: {0xFEF7, 0xFEF8},
+	0x0625: {0xFEF9, 0xFEFA},
+	0x0627: {0xFEFB, 0xFEFC},
+}
+
+const (
+	arabicLam     = 0x0644
+	arabicTatweel = 0x0640
+
+	devanagariSignI  = 0x093F
+	devanagariVirama = 0x094D
+	devanagariNukta  = 0x093C
+)
+
+// mirrors swaps paired punctuation in right-to-left runs.
+var mirrors = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+}
+
+var rtlLanguages = map[string]bool{
+	"ar": true,
+	"fa": true,
+	"he": true,
+}
+
+// isRTL reports whether lang is written right to left.
+func isRTL(lang string) bool {
+	return rtlLanguages[lang]
+}
+
+// shapeText returns s ready to be drawn left to right: Arabic letters take
+// their contextual forms and right-to-left runs are reordered. Text
+// without right-to-left characters is returned unchanged. The paragraph
+// direction comes from the first strong character.
+func shapeText(s string) string {
+	s = reorderDevanagari(s)
+
+	if !strings.ContainsFunc(s, isRTLRune) {
+		return s
+	}
+
+	return reorderBidi(shapeArabic([]rune(s)))
+}
+
+// isRTLText reports whether the first strong character of s is right to
+// left.
+func isRTLText(s string) bool {
+	for _, r := range s {
+		switch {
+		case isRTLRune(r) && !unicode.IsDigit(r):
+			return true
+		case unicode.IsLetter(r):
+			return false
+		}
+	}
+
+	return false
+}
+
+func isRTLRune(r rune) bool {
+	return unicode.In(r, unicode.Hebrew, unicode.Arabic)
+}
+
+// isTransparent reports whether r is a mark that does not break joining.
+func isTransparent(r rune) bool {
+	return unicode.Is(unicode.Mn, r)
+}
+
+// joinsBefore reports whether r connects to the letter preceding it.
+func joinsBefore(r rune) bool {
+	_, ok := arabicForms[r]
+
+	return ok && r != 0x0621 || r == arabicTatweel
+}
+
+// joinsAfter reports whether r connects to the letter following it.
+func joinsAfter(r rune) bool {
+	return arabicForms[r][2] != 0 || r == arabicTatweel
+}
+
+// shapeArabic replaces Arabic letters by the presentation form matching
+// their neighbours, forming lam-alef ligatures.
+func shapeArabic(runes []rune) []rune {
+	neighbour := func(i, step int) rune {
+		for i += step; i >= 0 && i < len(runes); i += step {
+			if !isTransparent(runes[i]) {
+				return runes[i]
+			}
+		}
+
+		return 0
+	}
+
+	out := make([]rune, 0, len(runes))
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		forms, ok := arabicForms[r]
+
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+
+		prev, next := neighbour(i, -1), neighbour(i, 1)
+		joinPrev := joinsAfter(prev) && joinsBefore(r)
+
+		if r == arabicLam && i+1 < len(runes) {
+			if ligature, ok := lamAlef[runes[i+1]]; ok {
+				if joinPrev {
+					out = append(out, ligature[1])
+				} else {
+					out = append(out, ligature[0])
+				}
+
+				i++
+				continue
+			}
+		}
+
+		joinNext := joinsAfter(r) && joinsBefore(next)
+
+		switch {
+		case joinPrev && joinNext:
+			out = append(out, forms[3])
+		case joinPrev:
+			out = append(out, forms[1])
+		case joinNext:
+			out = append(out, forms[2])
+		default:
+			out = append(out, forms[0])
+		}
+	}
+
+	return out
+}
+
+// reorderBidi converts logical order to visual order. It is a simplified
+// Unicode bidirectional algorithm, enough for labels and short banners:
+// digits read left to right, neutrals between two runs of the same
+// direction take it and other neutrals take the paragraph direction.
+// Explicit embeddings are not supported.
+func reorderBidi(runes []rune) string {
+	const (
+		neutral = iota
+		ltr
+		rtl
+	)
+
+	dirs := make([]int, len(runes))
+	paragraph := neutral
+
+	for i, r := range runes {
+		switch {
+		case isRTLRune(r) && !unicode.IsDigit(r):
+			dirs[i] = rtl
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			dirs[i] = ltr
+		case isTransparent(r) && i > 0:
+			dirs[i] = dirs[i-1]
+		}
+
+		if paragraph == neutral {
+			paragraph = dirs[i]
+		}
+	}
+
+	if paragraph == neutral {
+		paragraph = ltr
+	}
+
+	for i := 0; i < len(dirs); {
+		if dirs[i] != neutral {
+			i++
+			continue
+		}
+
+		j := i
+
+		for j < len(dirs) && dirs[j] == neutral {
+			j++
+		}
+
+		before, after := paragraph, paragraph
+
+		if i > 0 {
+			before = dirs[i-1]
+		}
+
+		if j < len(dirs) {
+			after = dirs[j]
+		}
+
+		dir := paragraph
+
+		if before == after {
+			dir = before
+		}
+
+		for k := i; k < j; k++ {
+			dirs[k] = dir
+		}
+
+		i = j
+	}
+
+	var runs [][]rune
+
+	for i := 0; i < len(runes); {
+		j := i
+
+		for j < len(runes) && dirs[j] == dirs[i] {
+			j++
+		}
+
+		run := append([]rune(nil), runes[i:j]...)
+
+		if dirs[i] == rtl {
+			run = reverseRun(run)
+		}
+
+		runs = append(runs, run)
+		i = j
+	}
+
+	if paragraph == rtl {
+		for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+			runs[i], runs[j] = runs[j], runs[i]
+		}
+	}
+
+	var b strings.Builder
+
+	for _, run := range runs {
+		b.WriteString(string(run))
+	}
+
+	return b.String()
+}
+
+// reverseRun reverses a right-to-left run, keeping marks after their base
+// and mirroring paired punctuation.
+func reverseRun(run []rune) []rune {
+	out := make([]rune, 0, len(run))
+
+	for end := len(run); end > 0; {
+		start := end - 1
+
+		for start > 0 && isTransparent(run[start]) {
+			start--
+		}
+
+		for _, r := range run[start:end] {
+			if m, ok := mirrors[r]; ok {
+				r = m
+			}
+
+			out = append(out, r)
+		}
+
+		end = start
+	}
+
+	return out
+}
+
+// reorderDevanagari moves each vowel sign i, which follows its consonant
+// cluster in logical order, in front of the cluster where it is drawn.
+func reorderDevanagari(s string) string {
+	if !strings.ContainsRune(s, devanagariSignI) {
+		return s
+	}
+
+	runes := []rune(s)
+
+	for i, r := range runes {
+		if r != devanagariSignI {
+			continue
+		}
+
+		start := i - 1
+
+		if start >= 0 && runes[start] == devanagariNukta {
+			start--
+		}
+
+		if start < 0 || !isDevanagariConsonant(runes[start]) {
+			continue
+		}
+
+		// Consonants joined by a virama form a single cluster
+		for start >= 2 && runes[start-1] == devanagariVirama && isDevanagariConsonant(runes[start-2]) {
+			start -= 2
+		}
+
+		copy(runes[start+1:i+1], runes[start:i])
+		runes[start] = r
+	}
+
+	return string(runes)
+}
+
+func isDevanagariConsonant(r rune) bool {
+	return r >= 0x0915 && r <= 0x0939 || r >= 0x0958 && r <= 0x095F
+}
@@ -0,0 +1,43 @@
+package render
+
+import "testing"
+
+func TestShapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"hello", "hello"},
+		{"שלום", "םולש"},
+		{"שלום abc 123 עולם", "םלוע abc 123 םולש"},
+		{"Sale (50%) מבצע", "Sale (50%) עצבמ"},
+		{"(מבצע)", "(עצבמ)"},
+		// beh, alef, beh: initial, final, isolated, drawn right to left
+		{"باب", "ﺏﺎﺑ"},
+		// lam-alef ligature after a joining letter
+		{"سلام", "ﻡﻼﺳ"},
+		{"أيام 9", "9 ﻡﺎﻳﺃ"},
+		// Devanagari vowel sign i moves before its consonant cluster
+		{"दिन", "िदन"},
+		{"मिनट", "िमनट"},
+		{"स्थिति", "िस्थित"},
+	}
+
+	for _, tt := range tests {
+		if got := shapeText(tt.in); got != tt.want {
+			t.Errorf("shapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUnitXFlipsForRTL(t *testing.T) {
+	units, _ := parseUnits(nil)
+
+	ltr := &Countdown{lang: "en", units: units, w: 700}
+	rtl := &Countdown{lang: "ar", units: units, w: 700}
+
+	for i := range units {
+		if got, want := rtl.unitX(i, 160), ltr.unitX(len(units)-1-i, 160); got != want {
+			t.Errorf("unit %d: x = %v, want %v", i, got, want)
+		}
+	}
+}
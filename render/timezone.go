@@ -0,0 +1,110 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // IANA names must resolve in wasm, which has no zoneinfo
+)
+
+// parseTimezone accepts an IANA name such as "America/Sao_Paulo" or a UTC
+// offset in hours, optionally fractional or as hh:mm ("-3", "5.5", "+05:30",
+// "UTC-03:00"). An empty string is UTC.
+func parseTimezone(s string) (*time.Location, error) {
+	s = strings.TrimSpace(s)
+
+	switch strings.ToUpper(s) {
+	case "", "Z", "UTC", "GMT":
+		return time.UTC, nil
+	}
+
+	if offset, ok := parseUTCOffset(s); ok {
+		return time.FixedZone(formatUTCOffset(offset), offset), nil
+	}
+
+	loc, err := time.LoadLocation(s)
+
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", s)
+	}
+
+	return loc, nil
+}
+
+// parseUTCOffset parses s as an offset east of UTC, in seconds.
+func parseUTCOffset(s string) (int, bool) {
+	upper := strings.ToUpper(s)
+
+	for _, prefix := range []string{"UTC", "GMT"} {
+		if strings.HasPrefix(upper, prefix) {
+			s = s[len(prefix):]
+			break
+		}
+	}
+
+	if s == "" {
+		return 0, false
+	}
+
+	sign := 1
+
+	switch s[0] {
+	case '+':
+		s = s[1:]
+	case '-':
+		sign = -1
+		s = s[1:]
+	}
+
+	var hours, minutes int
+	var err error
+
+	switch {
+	case strings.Contains(s, ":"):
+		parts := strings.SplitN(s, ":", 2)
+
+		if hours, err = strconv.Atoi(parts[0]); err != nil {
+			return 0, false
+		}
+
+		if minutes, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, false
+		}
+	case len(s) == 4 && !strings.Contains(s, "."):
+		if hours, err = strconv.Atoi(s[:2]); err != nil {
+			return 0, false
+		}
+
+		if minutes, err = strconv.Atoi(s[2:]); err != nil {
+			return 0, false
+		}
+	default:
+		h, err := strconv.ParseFloat(s, 64)
+
+		if err != nil {
+			return 0, false
+		}
+
+		hours = int(h)
+		minutes = int(math.Round((h - float64(hours)) * 60))
+	}
+
+	if hours > 14 || minutes < 0 || minutes > 59 {
+		return 0, false
+	}
+
+	return sign * (hours*3600 + minutes*60), true
+}
+
+func formatUTCOffset(offset int) string {
+	sign := "+"
+
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
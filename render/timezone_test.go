@@ -0,0 +1,93 @@
+package render
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimezone(t *testing.T) {
+	tests := []struct {
+		in     string
+		offset int
+	}{
+		{"", 0},
+		{"UTC", 0},
+		{"-3", -3 * 3600},
+		{"5.5", 5*3600 + 30*60},
+		{"+05:30", 5*3600 + 30*60},
+		{"-0930", -(9*3600 + 30*60)},
+		{"UTC-03:00", -3 * 3600},
+		{"GMT+5.75", 5*3600 + 45*60},
+		{"America/Sao_Paulo", -3 * 3600},
+		{"Asia/Kolkata", 5*3600 + 30*60},
+	}
+
+	instant := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		loc, err := parseTimezone(tt.in)
+
+		if err != nil {
+			t.Errorf("parseTimezone(%q) failed: %v", tt.in, err)
+			continue
+		}
+
+		if _, offset := instant.In(loc).Zone(); offset != tt.offset {
+			t.Errorf("parseTimezone(%q) offset = %d, want %d", tt.in, offset, tt.offset)
+		}
+	}
+
+	for _, in := range []string{"Mars/Olympus", "+15", "5:75", "UTC+"} {
+		if _, err := parseTimezone(in); err == nil {
+			t.Errorf("parseTimezone(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestCountdownTimezoneShiftsTarget(t *testing.T) {
+	now := time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC)
+
+	c, err := NewCountdown(CountdownOptions{
+		Now:        now,
+		TargetDate: "2026-01-01T00:00:00",
+		Timezone:   "America/Sao_Paulo",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := c.targetDate.Sub(now), 4*time.Hour; got != want {
+		t.Errorf("time left = %v, want %v", got, want)
+	}
+}
+
+func TestCountdownLegacyGMTShiftsTarget(t *testing.T) {
+	now := time.Date(2025, 12, 31, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		timezone string
+		want     time.Duration
+	}{
+		// Earlier versions added gmt to the target, so -3 ends 3 hours early
+		{"", time.Hour},
+		{"UTC", 4 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		c, err := NewCountdown(CountdownOptions{
+			GMT:        -3,
+			Now:        now,
+			TargetDate: "2026-01-01T00:00:00.000Z",
+			Timezone:   tt.timezone,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := c.targetDate.Sub(now); got != tt.want {
+			t.Errorf("timezone %q: time left = %v, want %v", tt.timezone, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"github.com/rivo/uniseg"
+	"golang.org/x/image/font"
+)
+
+// maxTypingSteps bounds the frame count, which grows with the text length.
+const maxTypingSteps = 200
+
+type TypingText struct {
+	bg      color.Color
+	color   color.Color
+	delay   float64
+	font    string
+	height  int
+	text    string
+	width   int
+	padding int
+	perWord bool
+
+	fontFace font.Face
+	steps    []int
+}
+
+type TypingTextOptions struct {
+	Background string
+	Color      string
+	Delay      float64
+	Font       string
+	Height     int
+	Text       string
+	Width      int
+	Padding    int
+	PerWord    bool // type a whole word per frame instead of a character
+}
+
+func NewTypingText(opts TypingTextOptions) (*TypingText, error) {
+	opts.Width = clampCanvasSide(opts.Width, 800)
+	opts.Height = clampCanvasSide(opts.Height, 200)
+
+	fontName, err := resolveFont(opts.Font)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TypingText{
+		bg:      parseHexString(opts.Background),
+		color:   parseHexString(opts.Color),
+		delay:   opts.Delay,
+		font:    fontName,
+		height:  opts.Height,
+		text:    strings.TrimSpace(opts.Text),
+		width:   opts.Width,
+		padding: opts.Padding,
+		perWord: opts.PerWord,
+	}
+
+	t.steps = typingSteps(t.text, t.perWord)
+
+	if len(t.steps) > maxTypingSteps {
+		return nil, &OptionError{Field: "text", Err: fmt.Errorf("more than %d characters to type", maxTypingSteps)}
+	}
+
+	// Calculate optimal font size
+	fontSize, err := t.calculateFontSize()
+	if err != nil {
+		return nil, err
+	}
+
+	fontFace, err := t.loadFont(fontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	t.fontFace = fontFace
+
+	return t, nil
+}
+
+func init() {
+	Register("typing", func(p Params) (Renderer, error) {
+		typer, err := NewTypingText(TypingTextOptions{
+			Background: p.String("background", p.String("bg", "#000000")),
+			Color:      p.String("color", "#ffffff"),
+			Delay:      p.Float("delay", 100),
+			Font:       p.String("font", ""),
+			Height:     p.Int("height", 200),
+			Text:       p.String("text", "BLACK FRIDAY"),
+			Width:      p.Int("width", 800),
+			Padding:    p.Int("padding", 40),
+			PerWord:    p.Bool("perWord", false),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return typer, nil
+	})
+}
+
+func (t *TypingText) Create() ([]byte, error) {
+	return Encode(t)
+}
+
+// Frames returns an empty frame, one frame per visible character (or word)
+// and some extra frames at the end with the cursor blinking.
+func (t *TypingText) Frames() int {
+	return len(t.steps) + 1 + 6
+}
+
+func (t *TypingText) Delay(i int) int {
+	return int(t.delay / 10)
+}
+
+func (t *TypingText) Palette(i int) color.Palette {
+	return t.generatePalette()
+}
+
+func (t *TypingText) Frame(i int) (image.Image, error) {
+	if i == 0 {
+		return t.createFrame(t.fontFace, 0, false), nil
+	}
+
+	if i <= len(t.steps) {
+		return t.createFrame(t.fontFace, t.steps[i-1], false), nil
+	}
+
+	return t.createFrame(t.fontFace, len(t.text), (i-len(t.steps)-1)%2 == 0), nil
+}
+
+// typingSteps returns the byte length of text shown by each typing frame:
+// one per grapheme cluster, so accents and emoji appear whole, or one per
+// word. Whitespace is typed along with the character following it, as it
+// would not change the frame on its own.
+func typingSteps(text string, perWord bool) []int {
+	var steps []int
+
+	state := -1
+	offset := 0
+
+	for rest := text; rest != ""; {
+		var cluster string
+
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		offset += len(cluster)
+
+		if isSpace(cluster) {
+			continue
+		}
+
+		if perWord && rest != "" && !isSpace(firstGraphemeCluster(rest)) {
+			continue
+		}
+
+		steps = append(steps, offset)
+	}
+
+	return steps
+}
+
+func firstGraphemeCluster(s string) string {
+	cluster, _, _, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+
+	return cluster
+}
+
+func isSpace(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+func (t *TypingText) calculateFontSize() (float64, error) {
+	fontSize := float64(t.height) * 0.5
+	dc := gg.NewContext(t.width, t.height)
+
+	maxWidth := float64(t.width - 2*t.padding)
+	maxHeight := float64(t.height - 2*t.padding)
+
+	for {
+		font, err := measureFont(t.font, fontSize, 144)
+		if err != nil {
+			return 0, err
+		}
+		dc.SetFontFace(font)
+
+		textWidth, textHeight := dc.MeasureString(shapeText(t.text) + "|")
+
+		if textWidth <= maxWidth && textHeight <= maxHeight {
+			return fontSize, nil
+		}
+
+		fontSize *= 0.9
+		if fontSize < 12 {
+			return 12, nil
+		}
+	}
+}
+
+// createFrame draws the first textLength bytes of the text, which always end
+// on a grapheme cluster boundary.
+func (t *TypingText) createFrame(fontFace font.Face, textLength int, showCursor bool) image.Image {
+	dc := gg.NewContext(t.width, t.height)
+
+	// Set background
+	dc.SetColor(t.bg)
+	dc.Clear()
+
+	dc.SetFontFace(fontFace)
+
+	// Get the visible portion of text
+	visibleText := shapeText(t.text[:textLength])
+
+	// Calculate vertical center
+	_, textHeight := dc.MeasureString("M")
+	y := (float64(t.height) + textHeight) / 2
+
+	width, _ := dc.MeasureString(visibleText)
+	x := float64(t.padding)
+	cursorX := x + width
+
+	// Right-to-left text grows leftwards from the right padding, with the
+	// cursor on its left
+	if isRTLText(t.text) {
+		cursorWidth, _ := dc.MeasureString("|")
+		x = float64(t.width-t.padding) - width
+		cursorX = x - cursorWidth
+	}
+
+	// Draw visible text with padding
+	dc.SetColor(t.color)
+	dc.DrawString(visibleText, x, y)
+
+	// Draw cursor if needed
+	if showCursor {
+		dc.DrawString("|", cursorX, y)
+	}
+
+	return dc.Image()
+}
+
+func (t *TypingText) generatePalette() color.Palette {
+	palette := make(color.Palette, 0, 256)
+	palette = append(palette, t.bg)
+	palette = append(palette, t.color)
+
+	// Create gradient between background and text color
+	r1, g1, b1, _ := t.bg.RGBA()
+	r2, g2, b2, _ := t.color.RGBA()
+
+	for i := 0; i < 254; i++ {
+		f := float64(i) / 253.0
+		r := uint8((1-f)*float64(r1>>8) + f*float64(r2>>8))
+		g := uint8((1-f)*float64(g1>>8) + f*float64(g2>>8))
+		b := uint8((1-f)*float64(b1>>8) + f*float64(b2>>8))
+		palette = append(palette, color.RGBA{r, g, b, 255})
+	}
+
+	return palette
+}
+
+func (t *TypingText) loadFont(size float64) (font.Face, error) {
+	return loadFont(t.font, size, 144)
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTypingSteps(t *testing.T) {
+	tests := []struct {
+		text    string
+		perWord bool
+		want    []string
+	}{
+		{"HI", false, []string{"H", "HI"}},
+		{"Café 👍🏽", false, []string{"C", "Ca", "Caf", "Café", "Café 👍🏽"}},
+		// e followed by a combining acute accent is a single character
+		{"cafe\u0301!", false, []string{"c", "ca", "caf", "cafe\u0301", "cafe\u0301!"}},
+		{"🇧🇷 go", false, []string{"🇧🇷", "🇧🇷 g", "🇧🇷 go"}},
+		{"Black  Friday sale", true, []string{"Black", "Black  Friday", "Black  Friday sale"}},
+		{"", false, nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+
+		for _, n := range typingSteps(tt.text, tt.perWord) {
+			if !utf8.ValidString(tt.text[:n]) {
+				t.Errorf("typingSteps(%q) splits a rune at byte %d", tt.text, n)
+			}
+
+			got = append(got, tt.text[:n])
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("typingSteps(%q, %v) = %q, want %q", tt.text, tt.perWord, got, tt.want)
+		}
+	}
+}
+
+func TestTypingTextFramesCountVisibleCharacters(t *testing.T) {
+	typer, err := NewTypingText(TypingTextOptions{
+		Background: "#000000",
+		Color:      "#ffffff",
+		Delay:      100,
+		Height:     200,
+		Text:       "Olá 👋🏽",
+		Width:      800,
+		Padding:    40,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Empty frame, four characters and six cursor blinks
+	if got, want := typer.Frames(), 1+4+6; got != want {
+		t.Errorf("Frames() = %d, want %d", got, want)
+	}
+}
+
+func TestTypingTextRejectsLongText(t *testing.T) {
+	_, err := NewTypingText(TypingTextOptions{
+		Text: strings.Repeat("a", maxTypingSteps+1),
+	})
+
+	var optErr *OptionError
+
+	if !errors.As(err, &optErr) || optErr.Field != "text" {
+		t.Errorf("NewTypingText error = %v, want OptionError for text", err)
+	}
+}